@@ -5,40 +5,142 @@ import {context} from '../models';
 
 export function Cancel():Promise<void>;
 
+export function CancelCall(arg1:string):Promise<void>;
+
+export function CheckHealth():Promise<Array<app.healthStatus>>;
+
+export function ClearConnectionTimeline(arg1:string):Promise<void>;
+
+export function ClearHistory():Promise<void>;
+
 export function CloseSend():Promise<void>;
 
+export function CloseSendCall(arg1:string):Promise<void>;
+
 export function Connect(arg1:any,arg2:any,arg3:boolean):Promise<void>;
 
+export function DeleteBenchmark(arg1:string,arg2:string):Promise<void>;
+
+export function DeleteHistoryEntry(arg1:string):Promise<void>;
+
+export function DeleteMockFixture(arg1:string):Promise<void>;
+
+export function DeleteStreamScript(arg1:string,arg2:string):Promise<void>;
+
 export function DeleteWorkspace(arg1:string):Promise<void>;
 
+export function DiffHistory(arg1:string,arg2:string,arg3:any):Promise<app.historyDiff>;
+
+export function DiffMessages(arg1:string,arg2:string,arg3:string,arg4:boolean,arg5:any):Promise<app.messageDiff>;
+
+export function Disconnect(arg1:string):Promise<void>;
+
 export function ExportCommands(arg1:string,arg2:string,arg3:any):Promise<app.commands>;
 
+export function ExportHAR(arg1:Array<string>):Promise<string>;
+
+export function ExportPostman(arg1:string):Promise<app.postmanExport>;
+
+export function FanOut(arg1:Array<string>,arg2:string,arg3:string,arg4:any,arg5:any):Promise<app.fanOutResult>;
+
 export function FindProtoFiles():Promise<Array<string>>;
 
+export function GenerateExample(arg1:string,arg2:any):Promise<string>;
+
+export function GetChannelz():Promise<app.channelzReport>;
+
+export function GetClientChannelz():Promise<app.channelzReport>;
+
+export function GetConnectionTimeline(arg1:string):Promise<Array<app.connEvent>>;
+
+export function GetHistoryEntry(arg1:string):Promise<app.historyEntry>;
+
 export function GetMetadata(arg1:string):Promise<app.headers>;
 
+export function GetMockFixture(arg1:string):Promise<app.mockFixture>;
+
+export function GetMockServer():Promise<app.mockStatus>;
+
+export function GetProxy():Promise<app.proxyStatus>;
+
 export function GetRawMessageState(arg1:string):Promise<string>;
 
 export function GetReflectMetadata(arg1:string):Promise<app.headers>;
 
+export function GetSchema():Promise<Array<app.schemaFile>>;
+
 export function GetWindowInfo():Promise<Record<string, any>>;
 
 export function GetWorkspaceOptions():Promise<app.options>;
 
-export function ImportCommand(arg1:string,arg2:string):Promise<void>;
+export function ImportCommand(arg1:string,arg2:string):Promise<app.importedCommand>;
+
+export function ImportHAR(arg1:string):Promise<number>;
+
+export function ImportPostman(arg1:string):Promise<app.postmanImport>;
+
+export function ListBenchmarks(arg1:string):Promise<Array<app.benchResult>>;
+
+export function ListCalls():Promise<Array<app.callInfo>>;
+
+export function ListConnections():Promise<Array<app.connectionInfo>>;
+
+export function ListHistory():Promise<Array<app.historyEntry>>;
+
+export function ListMockFixtures():Promise<Array<app.mockFixture>>;
+
+export function ListStreamScripts(arg1:string):Promise<Array<app.streamScript>>;
 
 export function ListWorkspaces():Promise<Array<app.options>>;
 
+export function PromoteHistoryEntry(arg1:string):Promise<app.mockFixture>;
+
+export function ReplayHistory(arg1:string,arg2:string,arg3:boolean,arg4:any):Promise<string>;
+
 export function RetryConnection():Promise<void>;
 
+export function RunStreamScript(arg1:string,arg2:any,arg3:any):Promise<string>;
+
+export function SaveMockFixture(arg1:any):Promise<void>;
+
+export function SaveStreamScript(arg1:string,arg2:any):Promise<void>;
+
+export function SearchSymbols(arg1:string,arg2:number):Promise<Array<app.symbolMatch>>;
+
 export function SelectDirectory():Promise<string>;
 
 export function SelectMethod(arg1:string,arg2:string,arg3:any):Promise<void>;
 
+export function SelectMethodExample(arg1:string,arg2:any,arg3:any):Promise<void>;
+
 export function SelectWorkspace(arg1:string):Promise<void>;
 
 export function Send(arg1:string,arg2:string,arg3:any):Promise<void>;
 
+export function SendStream(arg1:string,arg2:string):Promise<void>;
+
 export function Shutdown(arg1:context.Context):Promise<void>;
 
+export function StartBenchmark(arg1:string,arg2:string,arg3:any,arg4:any):Promise<string>;
+
+export function StartCall(arg1:string,arg2:string,arg3:string,arg4:any):Promise<string>;
+
+export function StartMockServer(arg1:any):Promise<app.mockStatus>;
+
+export function StartProxy(arg1:any):Promise<app.proxyStatus>;
+
+export function StopBenchmark(arg1:string):Promise<void>;
+
+export function StopMockServer():Promise<void>;
+
+export function StopProxy():Promise<void>;
+
+export function StopWatchHealth(arg1:string):Promise<void>;
+
+export function ValidateRequest(arg1:string,arg2:string):Promise<Array<app.fieldViolation>>;
+
+export function ValidateServiceConfig(arg1:string):Promise<void>;
+
 export function WailsShutdown():Promise<void>;
+
+export function WatchHealth(arg1:string):Promise<void>;
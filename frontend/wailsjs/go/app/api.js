@@ -6,30 +6,122 @@ export function Cancel() {
   return window['go']['app']['api']['Cancel']();
 }
 
+export function CancelCall(arg1) {
+  return window['go']['app']['api']['CancelCall'](arg1);
+}
+
+export function CheckHealth() {
+  return window['go']['app']['api']['CheckHealth']();
+}
+
+export function ClearConnectionTimeline(arg1) {
+  return window['go']['app']['api']['ClearConnectionTimeline'](arg1);
+}
+
+export function ClearHistory() {
+  return window['go']['app']['api']['ClearHistory']();
+}
+
 export function CloseSend() {
   return window['go']['app']['api']['CloseSend']();
 }
 
+export function CloseSendCall(arg1) {
+  return window['go']['app']['api']['CloseSendCall'](arg1);
+}
+
 export function Connect(arg1, arg2, arg3) {
   return window['go']['app']['api']['Connect'](arg1, arg2, arg3);
 }
 
+export function DeleteBenchmark(arg1, arg2) {
+  return window['go']['app']['api']['DeleteBenchmark'](arg1, arg2);
+}
+
+export function DeleteHistoryEntry(arg1) {
+  return window['go']['app']['api']['DeleteHistoryEntry'](arg1);
+}
+
+export function DeleteMockFixture(arg1) {
+  return window['go']['app']['api']['DeleteMockFixture'](arg1);
+}
+
+export function DeleteStreamScript(arg1, arg2) {
+  return window['go']['app']['api']['DeleteStreamScript'](arg1, arg2);
+}
+
 export function DeleteWorkspace(arg1) {
   return window['go']['app']['api']['DeleteWorkspace'](arg1);
 }
 
+export function DiffHistory(arg1, arg2, arg3) {
+  return window['go']['app']['api']['DiffHistory'](arg1, arg2, arg3);
+}
+
+export function DiffMessages(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['app']['api']['DiffMessages'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function Disconnect(arg1) {
+  return window['go']['app']['api']['Disconnect'](arg1);
+}
+
 export function ExportCommands(arg1, arg2, arg3) {
   return window['go']['app']['api']['ExportCommands'](arg1, arg2, arg3);
 }
 
+export function ExportHAR(arg1) {
+  return window['go']['app']['api']['ExportHAR'](arg1);
+}
+
+export function ExportPostman(arg1) {
+  return window['go']['app']['api']['ExportPostman'](arg1);
+}
+
+export function FanOut(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['app']['api']['FanOut'](arg1, arg2, arg3, arg4, arg5);
+}
+
 export function FindProtoFiles() {
   return window['go']['app']['api']['FindProtoFiles']();
 }
 
+export function GenerateExample(arg1, arg2) {
+  return window['go']['app']['api']['GenerateExample'](arg1, arg2);
+}
+
+export function GetChannelz() {
+  return window['go']['app']['api']['GetChannelz']();
+}
+
+export function GetClientChannelz() {
+  return window['go']['app']['api']['GetClientChannelz']();
+}
+
+export function GetConnectionTimeline(arg1) {
+  return window['go']['app']['api']['GetConnectionTimeline'](arg1);
+}
+
+export function GetHistoryEntry(arg1) {
+  return window['go']['app']['api']['GetHistoryEntry'](arg1);
+}
+
 export function GetMetadata(arg1) {
   return window['go']['app']['api']['GetMetadata'](arg1);
 }
 
+export function GetMockFixture(arg1) {
+  return window['go']['app']['api']['GetMockFixture'](arg1);
+}
+
+export function GetMockServer() {
+  return window['go']['app']['api']['GetMockServer']();
+}
+
+export function GetProxy() {
+  return window['go']['app']['api']['GetProxy']();
+}
+
 export function GetRawMessageState(arg1) {
   return window['go']['app']['api']['GetRawMessageState'](arg1);
 }
@@ -38,6 +130,10 @@ export function GetReflectMetadata(arg1) {
   return window['go']['app']['api']['GetReflectMetadata'](arg1);
 }
 
+export function GetSchema() {
+  return window['go']['app']['api']['GetSchema']();
+}
+
 export function GetWindowInfo() {
   return window['go']['app']['api']['GetWindowInfo']();
 }
@@ -50,14 +146,70 @@ export function ImportCommand(arg1, arg2) {
   return window['go']['app']['api']['ImportCommand'](arg1, arg2);
 }
 
+export function ImportHAR(arg1) {
+  return window['go']['app']['api']['ImportHAR'](arg1);
+}
+
+export function ImportPostman(arg1) {
+  return window['go']['app']['api']['ImportPostman'](arg1);
+}
+
+export function ListBenchmarks(arg1) {
+  return window['go']['app']['api']['ListBenchmarks'](arg1);
+}
+
+export function ListCalls() {
+  return window['go']['app']['api']['ListCalls']();
+}
+
+export function ListConnections() {
+  return window['go']['app']['api']['ListConnections']();
+}
+
+export function ListHistory() {
+  return window['go']['app']['api']['ListHistory']();
+}
+
+export function ListMockFixtures() {
+  return window['go']['app']['api']['ListMockFixtures']();
+}
+
+export function ListStreamScripts(arg1) {
+  return window['go']['app']['api']['ListStreamScripts'](arg1);
+}
+
 export function ListWorkspaces() {
   return window['go']['app']['api']['ListWorkspaces']();
 }
 
+export function PromoteHistoryEntry(arg1) {
+  return window['go']['app']['api']['PromoteHistoryEntry'](arg1);
+}
+
+export function ReplayHistory(arg1, arg2, arg3, arg4) {
+  return window['go']['app']['api']['ReplayHistory'](arg1, arg2, arg3, arg4);
+}
+
 export function RetryConnection() {
   return window['go']['app']['api']['RetryConnection']();
 }
 
+export function RunStreamScript(arg1, arg2, arg3) {
+  return window['go']['app']['api']['RunStreamScript'](arg1, arg2, arg3);
+}
+
+export function SaveMockFixture(arg1) {
+  return window['go']['app']['api']['SaveMockFixture'](arg1);
+}
+
+export function SaveStreamScript(arg1, arg2) {
+  return window['go']['app']['api']['SaveStreamScript'](arg1, arg2);
+}
+
+export function SearchSymbols(arg1, arg2) {
+  return window['go']['app']['api']['SearchSymbols'](arg1, arg2);
+}
+
 export function SelectDirectory() {
   return window['go']['app']['api']['SelectDirectory']();
 }
@@ -66,6 +218,10 @@ export function SelectMethod(arg1, arg2, arg3) {
   return window['go']['app']['api']['SelectMethod'](arg1, arg2, arg3);
 }
 
+export function SelectMethodExample(arg1, arg2, arg3) {
+  return window['go']['app']['api']['SelectMethodExample'](arg1, arg2, arg3);
+}
+
 export function SelectWorkspace(arg1) {
   return window['go']['app']['api']['SelectWorkspace'](arg1);
 }
@@ -74,10 +230,58 @@ export function Send(arg1, arg2, arg3) {
   return window['go']['app']['api']['Send'](arg1, arg2, arg3);
 }
 
+export function SendStream(arg1, arg2) {
+  return window['go']['app']['api']['SendStream'](arg1, arg2);
+}
+
 export function Shutdown(arg1) {
   return window['go']['app']['api']['Shutdown'](arg1);
 }
 
+export function StartBenchmark(arg1, arg2, arg3, arg4) {
+  return window['go']['app']['api']['StartBenchmark'](arg1, arg2, arg3, arg4);
+}
+
+export function StartCall(arg1, arg2, arg3, arg4) {
+  return window['go']['app']['api']['StartCall'](arg1, arg2, arg3, arg4);
+}
+
+export function StartMockServer(arg1) {
+  return window['go']['app']['api']['StartMockServer'](arg1);
+}
+
+export function StartProxy(arg1) {
+  return window['go']['app']['api']['StartProxy'](arg1);
+}
+
+export function StopBenchmark(arg1) {
+  return window['go']['app']['api']['StopBenchmark'](arg1);
+}
+
+export function StopMockServer() {
+  return window['go']['app']['api']['StopMockServer']();
+}
+
+export function StopProxy() {
+  return window['go']['app']['api']['StopProxy']();
+}
+
+export function StopWatchHealth(arg1) {
+  return window['go']['app']['api']['StopWatchHealth'](arg1);
+}
+
+export function ValidateRequest(arg1, arg2) {
+  return window['go']['app']['api']['ValidateRequest'](arg1, arg2);
+}
+
+export function ValidateServiceConfig(arg1) {
+  return window['go']['app']['api']['ValidateServiceConfig'](arg1);
+}
+
 export function WailsShutdown() {
   return window['go']['app']['api']['WailsShutdown']();
 }
+
+export function WatchHealth(arg1) {
+  return window['go']['app']['api']['WatchHealth'](arg1);
+}
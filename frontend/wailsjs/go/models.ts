@@ -1,71 +1,380 @@
 export namespace app {
 	
-	export class commands {
-	    grpcurl: string;
+	export class backoffOptions {
+	    base_delay: number;
+	    multiplier: number;
+	    jitter: number;
+	    max_delay: number;
+	    min_connect_timeout: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new commands(source);
+	        return new backoffOptions(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.grpcurl = source["grpcurl"];
+	        this.base_delay = source["base_delay"];
+	        this.multiplier = source["multiplier"];
+	        this.jitter = source["jitter"];
+	        this.max_delay = source["max_delay"];
+	        this.min_connect_timeout = source["min_connect_timeout"];
 	    }
 	}
-	export class header {
-	    key: string;
-	    val: string;
+	export class benchBucket {
+	    mark_ms: number;
+	    count: number;
+	    frequency: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new header(source);
+	        return new benchBucket(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.key = source["key"];
-	        this.val = source["val"];
+	        this.mark_ms = source["mark_ms"];
+	        this.count = source["count"];
+	        this.frequency = source["frequency"];
 	    }
 	}
-	export class protos {
-	    files: string[];
-	    roots: string[];
+	export class benchOptions {
+	    total: number;
+	    duration: number;
+	    concurrency: number;
+	    qps: number;
+	    connections: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new protos(source);
+	        return new benchOptions(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.files = source["files"];
-	        this.roots = source["roots"];
+	        this.total = source["total"];
+	        this.duration = source["duration"];
+	        this.concurrency = source["concurrency"];
+	        this.qps = source["qps"];
+	        this.connections = source["connections"];
 	    }
 	}
-	export class options {
+	export class benchPercentile {
+	    percentile: number;
+	    latency_ms: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new benchPercentile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.percentile = source["percentile"];
+	        this.latency_ms = source["latency_ms"];
+	    }
+	}
+	export class benchResult {
 	    id: string;
 	    addr: string;
-	    reflect: boolean;
-	    protos: protos;
-	    insecure: boolean;
-	    plaintext: boolean;
-	    rootca: string;
-	    clientcert: string;
-	    clientkey: string;
+	    method: string;
+	    options: benchOptions;
+	    // Go type: time
+	    started: any;
+	    elapsed_ms: number;
+	    count: number;
+	    errors: number;
+	    rps: number;
+	    fastest_ms: number;
+	    slowest_ms: number;
+	    average_ms: number;
+	    percentiles: benchPercentile[];
+	    histogram: benchBucket[];
+	    status_codes: Record<string, number>;
+	    cancelled: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new options(source);
+	        return new benchResult(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.id = source["id"];
 	        this.addr = source["addr"];
-	        this.reflect = source["reflect"];
-	        this.protos = this.convertValues(source["protos"], protos);
-	        this.insecure = source["insecure"];
-	        this.plaintext = source["plaintext"];
-	        this.rootca = source["rootca"];
-	        this.clientcert = source["clientcert"];
-	        this.clientkey = source["clientkey"];
+	        this.method = source["method"];
+	        this.options = this.convertValues(source["options"], benchOptions);
+	        this.started = this.convertValues(source["started"], null);
+	        this.elapsed_ms = source["elapsed_ms"];
+	        this.count = source["count"];
+	        this.errors = source["errors"];
+	        this.rps = source["rps"];
+	        this.fastest_ms = source["fastest_ms"];
+	        this.slowest_ms = source["slowest_ms"];
+	        this.average_ms = source["average_ms"];
+	        this.percentiles = this.convertValues(source["percentiles"], benchPercentile);
+	        this.histogram = this.convertValues(source["histogram"], benchBucket);
+	        this.status_codes = source["status_codes"];
+	        this.cancelled = source["cancelled"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class callInfo {
+	    id: string;
+	    workspace: string;
+	    method: string;
+	    client_stream: boolean;
+	    server_stream: boolean;
+	    started: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new callInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.workspace = source["workspace"];
+	        this.method = source["method"];
+	        this.client_stream = source["client_stream"];
+	        this.server_stream = source["server_stream"];
+	        this.started = source["started"];
+	    }
+	}
+	export class callPhase {
+	    name: string;
+	    offset_ms: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new callPhase(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.offset_ms = source["offset_ms"];
+	    }
+	}
+	export class channelzSocket {
+	    id: number;
+	    name: string;
+	    local: string;
+	    remote: string;
+	    security: string;
+	    streams_started: number;
+	    streams_succeeded: number;
+	    streams_failed: number;
+	    messages_sent: number;
+	    messages_received: number;
+	    keep_alives_sent: number;
+	    // Go type: time
+	    last_local_stream_created?: any;
+	    // Go type: time
+	    last_remote_stream_created?: any;
+	    // Go type: time
+	    last_message_sent?: any;
+	    // Go type: time
+	    last_message_received?: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new channelzSocket(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.local = source["local"];
+	        this.remote = source["remote"];
+	        this.security = source["security"];
+	        this.streams_started = source["streams_started"];
+	        this.streams_succeeded = source["streams_succeeded"];
+	        this.streams_failed = source["streams_failed"];
+	        this.messages_sent = source["messages_sent"];
+	        this.messages_received = source["messages_received"];
+	        this.keep_alives_sent = source["keep_alives_sent"];
+	        this.last_local_stream_created = this.convertValues(source["last_local_stream_created"], null);
+	        this.last_remote_stream_created = this.convertValues(source["last_remote_stream_created"], null);
+	        this.last_message_sent = this.convertValues(source["last_message_sent"], null);
+	        this.last_message_received = this.convertValues(source["last_message_received"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class channelzEvent {
+	    description: string;
+	    severity: string;
+	    // Go type: time
+	    time?: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new channelzEvent(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.description = source["description"];
+	        this.severity = source["severity"];
+	        this.time = this.convertValues(source["time"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class channelzChannel {
+	    id: number;
+	    name: string;
+	    target: string;
+	    state: string;
+	    calls_started: number;
+	    calls_succeeded: number;
+	    calls_failed: number;
+	    // Go type: time
+	    last_call_started?: any;
+	    events: channelzEvent[];
+	    channels: channelzChannel[];
+	    subchannels: channelzChannel[];
+	    sockets: channelzSocket[];
+	
+	    static createFrom(source: any = {}) {
+	        return new channelzChannel(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.target = source["target"];
+	        this.state = source["state"];
+	        this.calls_started = source["calls_started"];
+	        this.calls_succeeded = source["calls_succeeded"];
+	        this.calls_failed = source["calls_failed"];
+	        this.last_call_started = this.convertValues(source["last_call_started"], null);
+	        this.events = this.convertValues(source["events"], channelzEvent);
+	        this.channels = this.convertValues(source["channels"], channelzChannel);
+	        this.subchannels = this.convertValues(source["subchannels"], channelzChannel);
+	        this.sockets = this.convertValues(source["sockets"], channelzSocket);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class channelzServer {
+	    id: number;
+	    name: string;
+	    calls_started: number;
+	    calls_succeeded: number;
+	    calls_failed: number;
+	    // Go type: time
+	    last_call_started?: any;
+	    listen_sockets: channelzSocket[];
+	    sockets: channelzSocket[];
+	
+	    static createFrom(source: any = {}) {
+	        return new channelzServer(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.calls_started = source["calls_started"];
+	        this.calls_succeeded = source["calls_succeeded"];
+	        this.calls_failed = source["calls_failed"];
+	        this.last_call_started = this.convertValues(source["last_call_started"], null);
+	        this.listen_sockets = this.convertValues(source["listen_sockets"], channelzSocket);
+	        this.sockets = this.convertValues(source["sockets"], channelzSocket);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class channelzReport {
+	    channels: channelzChannel[];
+	    servers: channelzServer[];
+	
+	    static createFrom(source: any = {}) {
+	        return new channelzReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.channels = this.convertValues(source["channels"], channelzChannel);
+	        this.servers = this.convertValues(source["servers"], channelzServer);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -86,6 +395,1206 @@ export namespace app {
 		    return a;
 		}
 	}
+	
+	
+	export class codeSnippet {
+	    language: string;
+	    name: string;
+	    code: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new codeSnippet(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.language = source["language"];
+	        this.name = source["name"];
+	        this.code = source["code"];
+	    }
+	}
+	export class commands {
+	    grpcurl: string;
+	    curl: string;
+	    snippets: codeSnippet[];
+	
+	    static createFrom(source: any = {}) {
+	        return new commands(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.grpcurl = source["grpcurl"];
+	        this.curl = source["curl"];
+	        this.snippets = this.convertValues(source["snippets"], codeSnippet);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class comments {
+	    leading: string;
+	    trailing: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new comments(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.leading = source["leading"];
+	        this.trailing = source["trailing"];
+	    }
+	}
+	export class connEvent {
+	    // Go type: time
+	    time: any;
+	    kind: string;
+	    state: string;
+	    reason: string;
+	    remote_addr: string;
+	    local_addr: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new connEvent(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.time = this.convertValues(source["time"], null);
+	        this.kind = source["kind"];
+	        this.state = source["state"];
+	        this.reason = source["reason"];
+	        this.remote_addr = source["remote_addr"];
+	        this.local_addr = source["local_addr"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class connectionInfo {
+	    id: string;
+	    addr: string;
+	    state: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new connectionInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.addr = source["addr"];
+	        this.state = source["state"];
+	    }
+	}
+	export class diffEntry {
+	    path: string;
+	    old: string;
+	    new: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new diffEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.old = source["old"];
+	        this.new = source["new"];
+	    }
+	}
+	export class messageDiff {
+	    equal: boolean;
+	    added: diffEntry[];
+	    removed: diffEntry[];
+	    changed: diffEntry[];
+	
+	    static createFrom(source: any = {}) {
+	        return new messageDiff(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.equal = source["equal"];
+	        this.added = this.convertValues(source["added"], diffEntry);
+	        this.removed = this.convertValues(source["removed"], diffEntry);
+	        this.changed = this.convertValues(source["changed"], diffEntry);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class responseDiff {
+	    index: number;
+	    missing: string;
+	    diff?: messageDiff;
+	
+	    static createFrom(source: any = {}) {
+	        return new responseDiff(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.index = source["index"];
+	        this.missing = source["missing"];
+	        this.diff = this.convertValues(source["diff"], messageDiff);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class fanOutEntry {
+	    workspace_id: string;
+	    addr: string;
+	    call_id: string;
+	    status: string;
+	    status_code: number;
+	    status_message: string;
+	    duration_ms: number;
+	    responses: string[];
+	    trailer: Record<string, string[]>;
+	    error: string;
+	    equal: boolean;
+	    diffs: responseDiff[];
+	
+	    static createFrom(source: any = {}) {
+	        return new fanOutEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.workspace_id = source["workspace_id"];
+	        this.addr = source["addr"];
+	        this.call_id = source["call_id"];
+	        this.status = source["status"];
+	        this.status_code = source["status_code"];
+	        this.status_message = source["status_message"];
+	        this.duration_ms = source["duration_ms"];
+	        this.responses = source["responses"];
+	        this.trailer = source["trailer"];
+	        this.error = source["error"];
+	        this.equal = source["equal"];
+	        this.diffs = this.convertValues(source["diffs"], responseDiff);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class fanOutResult {
+	    method: string;
+	    baseline: string;
+	    equal: boolean;
+	    results: fanOutEntry[];
+	
+	    static createFrom(source: any = {}) {
+	        return new fanOutResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.method = source["method"];
+	        this.baseline = source["baseline"];
+	        this.equal = source["equal"];
+	        this.results = this.convertValues(source["results"], fanOutEntry);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class fieldViolation {
+	    field: string;
+	    rule: string;
+	    message: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new fieldViolation(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.field = source["field"];
+	        this.rule = source["rule"];
+	        this.message = source["message"];
+	    }
+	}
+	export class header {
+	    key: string;
+	    val: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new header(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.key = source["key"];
+	        this.val = source["val"];
+	    }
+	}
+	export class healthStatus {
+	    workspace_id: string;
+	    service: string;
+	    status: string;
+	    error: string;
+	    watching: boolean;
+	    // Go type: time
+	    time: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new healthStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.workspace_id = source["workspace_id"];
+	        this.service = source["service"];
+	        this.status = source["status"];
+	        this.error = source["error"];
+	        this.watching = source["watching"];
+	        this.time = this.convertValues(source["time"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class historyDiff {
+	    left_status: string;
+	    right_status: string;
+	    equal: boolean;
+	    messages: responseDiff[];
+	
+	    static createFrom(source: any = {}) {
+	        return new historyDiff(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.left_status = source["left_status"];
+	        this.right_status = source["right_status"];
+	        this.equal = source["equal"];
+	        this.messages = this.convertValues(source["messages"], responseDiff);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class recordedMessage {
+	    outbound: boolean;
+	    offset_ms: number;
+	    json: string;
+	    size: number;
+	    compressed_size: number;
+	    wire_size: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new recordedMessage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.outbound = source["outbound"];
+	        this.offset_ms = source["offset_ms"];
+	        this.json = source["json"];
+	        this.size = source["size"];
+	        this.compressed_size = source["compressed_size"];
+	        this.wire_size = source["wire_size"];
+	    }
+	}
+	export class historyEntry {
+	    id: string;
+	    call_id: string;
+	    addr: string;
+	    method: string;
+	    client_stream: boolean;
+	    server_stream: boolean;
+	    // Go type: time
+	    started: any;
+	    duration_ms: number;
+	    header: Record<string, string[]>;
+	    response_header: Record<string, string[]>;
+	    trailer: Record<string, string[]>;
+	    status: string;
+	    status_code: number;
+	    status_message: string;
+	    messages: recordedMessage[];
+	    phases: callPhase[];
+	    imported: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new historyEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.call_id = source["call_id"];
+	        this.addr = source["addr"];
+	        this.method = source["method"];
+	        this.client_stream = source["client_stream"];
+	        this.server_stream = source["server_stream"];
+	        this.started = this.convertValues(source["started"], null);
+	        this.duration_ms = source["duration_ms"];
+	        this.header = source["header"];
+	        this.response_header = source["response_header"];
+	        this.trailer = source["trailer"];
+	        this.status = source["status"];
+	        this.status_code = source["status_code"];
+	        this.status_message = source["status_message"];
+	        this.messages = this.convertValues(source["messages"], recordedMessage);
+	        this.phases = this.convertValues(source["phases"], callPhase);
+	        this.imported = source["imported"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class keepaliveOptions {
+	    time: number;
+	    timeout: number;
+	    permit_without_stream: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new keepaliveOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.time = source["time"];
+	        this.timeout = source["timeout"];
+	        this.permit_without_stream = source["permit_without_stream"];
+	    }
+	}
+	export class protos {
+	    files: string[];
+	    roots: string[];
+	    protosets: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new protos(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.files = source["files"];
+	        this.roots = source["roots"];
+	        this.protosets = source["protosets"];
+	    }
+	}
+	export class options {
+	    id: string;
+	    addr: string;
+	    reflect: boolean;
+	    protos: protos;
+	    transport: string;
+	    codec: string;
+	    insecure: boolean;
+	    plaintext: boolean;
+	    rootca: string;
+	    clientcert: string;
+	    clientkey: string;
+	    server_name: string;
+	    authority: string;
+	    service_config: string;
+	    connect_timeout: number;
+	    keepalive: keepaliveOptions;
+	    backoff: backoffOptions;
+	    timeout: number;
+	    input_format: string;
+	    strict_fields: boolean;
+	    skip_validation: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new options(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.addr = source["addr"];
+	        this.reflect = source["reflect"];
+	        this.protos = this.convertValues(source["protos"], protos);
+	        this.transport = source["transport"];
+	        this.codec = source["codec"];
+	        this.insecure = source["insecure"];
+	        this.plaintext = source["plaintext"];
+	        this.rootca = source["rootca"];
+	        this.clientcert = source["clientcert"];
+	        this.clientkey = source["clientkey"];
+	        this.server_name = source["server_name"];
+	        this.authority = source["authority"];
+	        this.service_config = source["service_config"];
+	        this.connect_timeout = source["connect_timeout"];
+	        this.keepalive = this.convertValues(source["keepalive"], keepaliveOptions);
+	        this.backoff = this.convertValues(source["backoff"], backoffOptions);
+	        this.timeout = source["timeout"];
+	        this.input_format = source["input_format"];
+	        this.strict_fields = source["strict_fields"];
+	        this.skip_validation = source["skip_validation"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class importedCommand {
+	    workspace: options;
+	    method: string;
+	    data: string;
+	    metadata: header[];
+	    reflect_metadata: header[];
+	
+	    static createFrom(source: any = {}) {
+	        return new importedCommand(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.workspace = this.convertValues(source["workspace"], options);
+	        this.method = source["method"];
+	        this.data = source["data"];
+	        this.metadata = this.convertValues(source["metadata"], header);
+	        this.reflect_metadata = this.convertValues(source["reflect_metadata"], header);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	export class mockResponse {
+	    body: string;
+	    delay: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new mockResponse(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.body = source["body"];
+	        this.delay = source["delay"];
+	    }
+	}
+	export class mockFixture {
+	    method: string;
+	    responses: mockResponse[];
+	    latency: number;
+	    code: number;
+	    message: string;
+	    header: header[];
+	    trailer: header[];
+	
+	    static createFrom(source: any = {}) {
+	        return new mockFixture(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.method = source["method"];
+	        this.responses = this.convertValues(source["responses"], mockResponse);
+	        this.latency = source["latency"];
+	        this.code = source["code"];
+	        this.message = source["message"];
+	        this.header = this.convertValues(source["header"], header);
+	        this.trailer = this.convertValues(source["trailer"], header);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class mockStatus {
+	    addr: string;
+	    services: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new mockStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.addr = source["addr"];
+	        this.services = source["services"];
+	    }
+	}
+	
+	export class postmanExport {
+	    collection: string;
+	    unsupported: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new postmanExport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.collection = source["collection"];
+	        this.unsupported = source["unsupported"];
+	    }
+	}
+	export class postmanImport {
+	    name: string;
+	    workspaces: options[];
+	    requests: number;
+	    unsupported: string[];
+	    replaced: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new postmanImport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.workspaces = this.convertValues(source["workspaces"], options);
+	        this.requests = source["requests"];
+	        this.unsupported = source["unsupported"];
+	        this.replaced = source["replaced"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class proxyStatus {
+	    addr: string;
+	    workspace_id: string;
+	    target: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new proxyStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.addr = source["addr"];
+	        this.workspace_id = source["workspace_id"];
+	        this.target = source["target"];
+	    }
+	}
+	
+	
+	export class schemaEnumValue {
+	    name: string;
+	    number: number;
+	    comments: comments;
+	    deprecated: boolean;
+	    options: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new schemaEnumValue(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.number = source["number"];
+	        this.comments = this.convertValues(source["comments"], comments);
+	        this.deprecated = source["deprecated"];
+	        this.options = source["options"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class schemaEnum {
+	    name: string;
+	    full_name: string;
+	    comments: comments;
+	    deprecated: boolean;
+	    options: string;
+	    values: schemaEnumValue[];
+	
+	    static createFrom(source: any = {}) {
+	        return new schemaEnum(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.full_name = source["full_name"];
+	        this.comments = this.convertValues(source["comments"], comments);
+	        this.deprecated = source["deprecated"];
+	        this.options = source["options"];
+	        this.values = this.convertValues(source["values"], schemaEnumValue);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class schemaField {
+	    name: string;
+	    full_name: string;
+	    number: number;
+	    json_name: string;
+	    kind: string;
+	    cardinality: string;
+	    type_name: string;
+	    oneof: string;
+	    optional: boolean;
+	    default: string;
+	    comments: comments;
+	    deprecated: boolean;
+	    options: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new schemaField(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.full_name = source["full_name"];
+	        this.number = source["number"];
+	        this.json_name = source["json_name"];
+	        this.kind = source["kind"];
+	        this.cardinality = source["cardinality"];
+	        this.type_name = source["type_name"];
+	        this.oneof = source["oneof"];
+	        this.optional = source["optional"];
+	        this.default = source["default"];
+	        this.comments = this.convertValues(source["comments"], comments);
+	        this.deprecated = source["deprecated"];
+	        this.options = source["options"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class schemaOneof {
+	    name: string;
+	    comments: comments;
+	    fields: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new schemaOneof(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.comments = this.convertValues(source["comments"], comments);
+	        this.fields = source["fields"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class schemaMessage {
+	    name: string;
+	    full_name: string;
+	    comments: comments;
+	    deprecated: boolean;
+	    options: string;
+	    map_entry: boolean;
+	    fields: schemaField[];
+	    oneofs: schemaOneof[];
+	
+	    static createFrom(source: any = {}) {
+	        return new schemaMessage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.full_name = source["full_name"];
+	        this.comments = this.convertValues(source["comments"], comments);
+	        this.deprecated = source["deprecated"];
+	        this.options = source["options"];
+	        this.map_entry = source["map_entry"];
+	        this.fields = this.convertValues(source["fields"], schemaField);
+	        this.oneofs = this.convertValues(source["oneofs"], schemaOneof);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class schemaMethod {
+	    name: string;
+	    full_name: string;
+	    input: string;
+	    output: string;
+	    client_stream: boolean;
+	    server_stream: boolean;
+	    comments: comments;
+	    deprecated: boolean;
+	    options: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new schemaMethod(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.full_name = source["full_name"];
+	        this.input = source["input"];
+	        this.output = source["output"];
+	        this.client_stream = source["client_stream"];
+	        this.server_stream = source["server_stream"];
+	        this.comments = this.convertValues(source["comments"], comments);
+	        this.deprecated = source["deprecated"];
+	        this.options = source["options"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class schemaService {
+	    name: string;
+	    full_name: string;
+	    comments: comments;
+	    deprecated: boolean;
+	    options: string;
+	    methods: schemaMethod[];
+	
+	    static createFrom(source: any = {}) {
+	        return new schemaService(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.full_name = source["full_name"];
+	        this.comments = this.convertValues(source["comments"], comments);
+	        this.deprecated = source["deprecated"];
+	        this.options = source["options"];
+	        this.methods = this.convertValues(source["methods"], schemaMethod);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class schemaFile {
+	    name: string;
+	    package: string;
+	    syntax: string;
+	    comments: comments;
+	    deprecated: boolean;
+	    options: string;
+	    services: schemaService[];
+	    messages: schemaMessage[];
+	    enums: schemaEnum[];
+	
+	    static createFrom(source: any = {}) {
+	        return new schemaFile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.package = source["package"];
+	        this.syntax = source["syntax"];
+	        this.comments = this.convertValues(source["comments"], comments);
+	        this.deprecated = source["deprecated"];
+	        this.options = source["options"];
+	        this.services = this.convertValues(source["services"], schemaService);
+	        this.messages = this.convertValues(source["messages"], schemaMessage);
+	        this.enums = this.convertValues(source["enums"], schemaEnum);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	
+	
+	export class streamStep {
+	    kind: string;
+	    message: string;
+	    delay: number;
+	    count: number;
+	    match: string;
+	    timeout: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new streamStep(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.kind = source["kind"];
+	        this.message = source["message"];
+	        this.delay = source["delay"];
+	        this.count = source["count"];
+	        this.match = source["match"];
+	        this.timeout = source["timeout"];
+	    }
+	}
+	export class streamScript {
+	    name: string;
+	    steps: streamStep[];
+	
+	    static createFrom(source: any = {}) {
+	        return new streamScript(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.steps = this.convertValues(source["steps"], streamStep);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class symbolMatch {
+	    kind: string;
+	    name: string;
+	    full_name: string;
+	    file: string;
+	    deprecated: boolean;
+	    score: number;
+	    methods: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new symbolMatch(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.kind = source["kind"];
+	        this.name = source["name"];
+	        this.full_name = source["full_name"];
+	        this.file = source["file"];
+	        this.deprecated = source["deprecated"];
+	        this.score = source["score"];
+	        this.methods = source["methods"];
+	    }
+	}
 
 }
 
@@ -20,12 +20,10 @@ import (
 
 	"github.com/mitchellh/mapstructure"
 	"github.com/wailsapp/wails/v2/pkg/runtime"
-	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/stats"
 	"google.golang.org/grpc/status"
-	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/encoding/prototext"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/protoadapt"
@@ -42,26 +40,38 @@ const (
 	metadataKeyPrefix        = "md_"
 	reflectMetadataKeyPrefix = "rmd_"
 	messageKeyPrefix         = "msg_"
+	timelineKeyPrefix        = "tl_"
+	benchKeyPrefix           = "bench_"
+	scriptKeyPrefix          = "script_"
+	historyKeyPrefix         = "hist_"
+	mockKeyPrefix            = "mock_"
 )
 
 type api struct {
-	ctx              context.Context
-	client           *client
-	store            *store
-	protofiles       *protoregistry.Files
-	streamReq        chan proto.Message
-	cancelMonitoring context.CancelFunc
-	cancelInFlight   context.CancelFunc
-	mu               sync.Mutex // protect in-flight requests
-	inFlight         bool
-	appData          string
-	state            *workspaceState
+	ctx        context.Context
+	store      *store
+	conns      *connManager
+	mu         sync.Mutex // protect in-flight requests
+	calls      map[string]*call
+	lastCallID string
+	benchmarks map[string]context.CancelFunc
+	// healthWatches are keyed by workspace ID and service
+	healthWatches map[string]context.CancelFunc
+	mock          *mockServer
+	proxy         *proxy
+	appData       string
+	state         *workspaceState
+	// started is when the app started, the beginning of the session
+	started time.Time
 }
 
 type statsHandler struct {
 	*api
+	timeline *connTimeline
 }
 
+type ctxConnKey struct{}
+
 type storeLogger struct {
 	ctx     context.Context
 	log     *slog.Logger
@@ -101,12 +111,18 @@ func (s *storeLogger) Errorf(format string, args ...interface{}) {
 }
 
 func NewApp() *api {
-	return &api{}
+	return &api{
+		conns:         newConnManager(),
+		calls:         make(map[string]*call),
+		benchmarks:    make(map[string]context.CancelFunc),
+		healthWatches: make(map[string]context.CancelFunc),
+	}
 }
 
 // Startup is the initialization function for the Wails v2 runtime
 func (a *api) Startup(ctx context.Context) {
 	a.ctx = ctx
+	a.started = time.Now()
 
 	var err error
 	a.store, err = newStore(a.appData, newStoreLogger(ctx))
@@ -148,16 +164,29 @@ func (a *api) checkForUpdate() {
 
 // Shutdown is called when the application is closing
 func (a *api) Shutdown(ctx context.Context) {
+	a.StopMockServer()
+	a.StopProxy()
 	a.store.close()
-	if a.cancelMonitoring != nil {
-		a.cancelMonitoring()
-	}
-	if a.cancelInFlight != nil {
-		a.cancelInFlight()
-	}
-	if a.client != nil {
-		a.client.close()
-	}
+	a.cancelAllCalls()
+	a.conns.closeAll()
+}
+
+// currentID returns the ID of the current workspace
+func (a *api) currentID() string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.state.CurrentID
+}
+
+func (a *api) setCurrentID(id string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.state.CurrentID = id
+}
+
+// current returns the connection of the current workspace, if any
+func (a *api) current() *connection {
+	return a.conns.get(a.currentID())
 }
 
 func (a *api) emitError(title, msg string) {
@@ -184,8 +213,13 @@ func (a *api) getCurrentState() *workspaceState {
 
 // GetWorkspaceOptions gets the workspace options from the store
 func (a *api) GetWorkspaceOptions() (*options, error) {
+	return a.workspaceOptions(a.currentID())
+}
+
+// workspaceOptions gets the workspace options from the store by ID
+func (a *api) workspaceOptions(id string) (*options, error) {
 	wo := &options{
-		ID: a.state.CurrentID,
+		ID: id,
 	}
 
 	val, err := a.store.get([]byte(wo.ID))
@@ -212,16 +246,11 @@ func (a *api) GetWorkspaceOptions() (*options, error) {
 
 // WailsShutdown is the shutdown function that is called when wails shuts down
 func (a *api) WailsShutdown() {
+	a.StopMockServer()
+	a.StopProxy()
 	a.store.close()
-	if a.cancelMonitoring != nil {
-		a.cancelMonitoring()
-	}
-	if a.cancelInFlight != nil {
-		a.cancelInFlight()
-	}
-	if a.client != nil {
-		a.client.close()
-	}
+	a.cancelAllCalls()
+	a.conns.closeAll()
 }
 
 // GetReflectMetadata gets the reflection metadata from the store by addr
@@ -281,7 +310,7 @@ func (a *api) ListWorkspaces() ([]options, error) {
 
 // SelectWorkspace changes the current workspace by ID
 func (a *api) SelectWorkspace(id string) (rerr error) {
-	if a.state.CurrentID == id {
+	if a.currentID() == id {
 		return nil
 	}
 
@@ -292,16 +321,13 @@ func (a *api) SelectWorkspace(id string) (rerr error) {
 		}
 	}()
 
-	if a.client != nil {
-		if a.cancelMonitoring != nil {
-			a.cancelMonitoring()
-			time.Sleep(100 * time.Millisecond)
-		}
-		a.client.close()
-		a.client = nil
+	// The connection of the previous workspace is kept open, so any of its
+	// in-flight calls are able to complete.
+	a.changeWorkspace(id)
+	if conn := a.conns.get(id); conn != nil && conn.state() != connectivity.Shutdown {
+		return a.activateConnection(conn)
 	}
 
-	a.changeWorkspace(id)
 	opts, err := a.GetWorkspaceOptions()
 	if err != nil {
 		return err
@@ -322,7 +348,9 @@ func (a *api) SelectWorkspace(id string) (rerr error) {
 // the default workspace, if the deleted workspace is current.
 func (a *api) DeleteWorkspace(id string) error {
 	a.store.del([]byte(id))
-	if a.state.CurrentID == id {
+	a.conns.remove(id, nil)
+	a.newConnTimeline(id).clear()
+	if a.currentID() == id {
 		a.SelectWorkspace(defaultWorkspaceKey)
 	}
 	return nil
@@ -377,7 +405,13 @@ func (a *api) SelectDirectory() (string, error) {
 }
 
 // Connect will attempt to connect a grpc server and parse any proto files
-func (a *api) Connect(data, rawHeaders interface{}, save bool) (rerr error) {
+func (a *api) Connect(data, rawHeaders interface{}, save bool) error {
+	return a.connect(data, rawHeaders, save, nil)
+}
+
+// connect connects the workspace of the options, selecting the method of the
+// imported command, if any, once its proto files are loaded
+func (a *api) connect(data, rawHeaders interface{}, save bool, imported *importedCommand) (rerr error) {
 	defer func() {
 		if rerr != nil {
 			const errTitle = "Connection error"
@@ -392,72 +426,156 @@ func (a *api) Connect(data, rawHeaders interface{}, save bool) (rerr error) {
 		return err
 	}
 
+	if err := validateServiceConfig(opts.ServiceConfig); err != nil {
+		return fmt.Errorf("invalid service config: %v", err)
+	}
+	if err := validateTransport(opts); err != nil {
+		return err
+	}
+	if err := validateKeepalive(opts.Keepalive); err != nil {
+		return err
+	}
+
+	prevID := a.currentID()
+	isNew := false
+	if opts.ID == "" {
+		if save {
+			id := uuid.Must(uuid.NewV4())
+			opts.ID = workspacePrefix + id.String()
+			isNew = true
+		} else {
+			opts.ID = prevID
+		}
+	}
+	// The workspace being connected becomes current straight away, so its
+	// state changes are sent to the frontend; it's only persisted on success.
+	a.setCurrentID(opts.ID)
+
 	// reset all things
 	runtime.EventsEmit(a.ctx, eventClientConnectStarted, opts.Addr)
 	runtime.EventsEmit(a.ctx, eventServicesSelectChanged)
 	runtime.EventsEmit(a.ctx, eventMethodInputChanged)
 
-	if a.client != nil {
-		if err := a.client.close(); err != nil {
-			return fmt.Errorf("failed to close previous connection: %v", err)
-		}
+	conn := newConnection(opts.ID, opts, a.newConnTimeline(opts.ID))
+	conn.imported = imported
+	if err := a.conns.put(conn); err != nil {
+		return fmt.Errorf("failed to close previous connection: %v", err)
 	}
-	a.client = &client{}
 
-	if a.cancelMonitoring != nil {
-		a.cancelMonitoring()
+	var ctx context.Context
+	ctx, conn.cancelMonitoring = context.WithCancel(context.Background())
+	if !isHTTPTransport(opts.Transport) {
+		go a.monitorStateChanges(ctx, conn)
 	}
-	ctx := context.Background()
-	ctx, a.cancelMonitoring = context.WithCancel(ctx)
-	go a.monitorStateChanges(ctx)
 
 	var hds headers
 	if err := mapstructure.Decode(rawHeaders, &hds); err != nil {
 		runtime.LogError(a.ctx, fmt.Sprintf("unable to decode reflection metadata headers: %v", err))
 	}
 
-	if err := a.client.connect(opts, statsHandler{a}); err != nil {
-		// Still try to parse proto definitions. Will fail silently
-		// if using reflection services as there is no connection
-		// to a valid server.
-		a.cancelMonitoring()
-		a.client = nil
-		go a.loadProtoFiles(opts, hds, true)
+	if err := conn.client.connect(opts, statsHandler{a, conn.client.timeline}); err != nil {
+		conn.close()
+		if isNew {
+			a.conns.remove(conn.id, conn)
+			a.setCurrentID(prevID)
+		} else {
+			// Still try to parse proto definitions. Will fail silently
+			// if using reflection services as there is no connection
+			// to a valid server.
+			go a.loadProtoFiles(conn, hds, true)
+		}
 
 		return fmt.Errorf("failed to connect to server: %v", err)
 	}
 
+	if opts.ID != prevID {
+		a.changeWorkspace(opts.ID)
+	}
+
 	runtime.EventsEmit(a.ctx, eventClientConnected, opts.Addr)
+	if isHTTPTransport(opts.Transport) {
+		// There is no long lived connection to monitor; each call is a
+		// separate HTTP request.
+		runtime.EventsEmit(a.ctx, eventClientStateChanged, connectivity.Ready.String())
+	}
 
-	go a.loadProtoFiles(opts, hds, false)
+	go func() {
+		a.loadProtoFiles(conn, hds, false)
+		if !isHTTPTransport(opts.Transport) {
+			a.checkHealth(conn)
+		}
+	}()
 
 	if !save {
 		return nil
 	}
 
-	if opts.ID == "" {
-		id := uuid.Must(uuid.NewV4())
-		opts.ID = workspacePrefix + id.String()
-		a.changeWorkspace(opts.ID)
-	}
-
 	go a.setWorkspaceOptions(opts)
 	go a.setMetadata(reflectMetadataKeyPrefix+hash(opts.Addr), hds)
 
 	return nil
 }
 
+// ValidateServiceConfig checks a service config JSON document, covering
+// methodConfig retry and hedging policies, timeouts and loadBalancingConfig
+func (a *api) ValidateServiceConfig(raw string) error {
+	return validateServiceConfig(raw)
+}
+
+// activateConnection makes an already open connection the one shown in the
+// frontend, without reconnecting or reloading its schema
+func (a *api) activateConnection(conn *connection) error {
+	runtime.EventsEmit(a.ctx, eventClientConnectStarted, conn.opts.Addr)
+	runtime.EventsEmit(a.ctx, eventServicesSelectChanged)
+	runtime.EventsEmit(a.ctx, eventMethodInputChanged)
+	runtime.EventsEmit(a.ctx, eventClientConnected, conn.opts.Addr)
+	runtime.EventsEmit(a.ctx, eventClientStateChanged, conn.state().String())
+
+	return a.emitServicesSelect("", "", nil)
+}
+
+// ListConnections returns the open connections of all workspaces
+func (a *api) ListConnections() []connectionInfo {
+	var infos []connectionInfo
+	for _, conn := range a.conns.list() {
+		infos = append(infos, connectionInfo{
+			ID:    conn.id,
+			Addr:  conn.opts.Addr,
+			State: conn.state().String(),
+		})
+	}
+	sort.SliceStable(infos, func(i, j int) bool {
+		return infos[i].ID < infos[j].ID
+	})
+	return infos
+}
+
+// Disconnect closes the connection of a workspace, cancelling its in-flight calls
+func (a *api) Disconnect(id string) error {
+	a.cancelCalls(id)
+	if err := a.conns.remove(id, nil); err != nil {
+		return err
+	}
+	if id == a.currentID() {
+		runtime.EventsEmit(a.ctx, eventClientStateChanged, connectivity.Shutdown.String())
+	}
+	return nil
+}
+
 func (a *api) changeWorkspace(id string) {
+	a.mu.Lock()
 	a.state.CurrentID = id
 	var val bytes.Buffer
 	enc := gob.NewEncoder(&val)
 	enc.Encode(a.state)
+	a.mu.Unlock()
 
 	a.store.set([]byte(defaultStateKey), val.Bytes())
 }
 
-func (a *api) loadProtoFiles(opts options, reflectHeaders headers, silent bool) (rerr error) {
+func (a *api) loadProtoFiles(conn *connection, reflectHeaders headers, silent bool) (rerr error) {
 	defer func() {
+		conn.setLoaded()
 		if rerr != nil {
 			const errTitle = "Failed to load RPC schema"
 			runtime.LogError(a.ctx, rerr.Error())
@@ -467,11 +585,13 @@ func (a *api) loadProtoFiles(opts options, reflectHeaders headers, silent bool)
 		}
 	}()
 
-	a.protofiles = nil
+	conn.setFiles(nil)
+	opts := conn.opts
 
+	var files *protoregistry.Files
 	var err error
 	if opts.Reflect {
-		if a.client == nil {
+		if conn.client == nil {
 			return errors.New("unable to load proto files via reflection: client is <nil>")
 		}
 		ctx := metadata.NewOutgoingContext(context.Background(), metadata.New(nil))
@@ -484,32 +604,50 @@ func (a *api) loadProtoFiles(opts options, reflectHeaders headers, silent bool)
 		}
 
 		ctx = context.WithValue(ctx, ctxInternalKey{}, struct{}{})
-		if a.protofiles, err = protoFilesFromReflectionAPI(ctx, a.client.conn); err != nil {
+		if files, err = protoFilesFromReflectionAPI(ctx, conn.client.grpcConn()); err != nil {
 			return fmt.Errorf("error getting proto files from reflection API: %v", err)
 		}
 	}
-	if !opts.Reflect && len(opts.Protos.Files) > 0 {
-		if a.protofiles, err = protoFilesFromDisk(opts.Protos.Roots, opts.Protos.Files); err != nil {
+	if !opts.Reflect && len(opts.Protos.Protosets) > 0 {
+		if files, err = protoFilesFromProtosets(opts.Protos.Protosets); err != nil {
+			return fmt.Errorf("error reading protosets: %v", err)
+		}
+	} else if !opts.Reflect && len(opts.Protos.Files) > 0 {
+		if files, err = protoFilesFromDisk(opts.Protos.Roots, opts.Protos.Files); err != nil {
 			return fmt.Errorf("error parsing proto files from disk: %v", err)
 		}
 	}
+	conn.setFiles(files)
 
+	if conn.id != a.currentID() {
+		return nil
+	}
+	if imp := conn.takeImported(); imp != nil {
+		if err := imp.resolve(conn); err != nil {
+			return err
+		}
+		return a.emitServicesSelect(imp.Method, imp.Data, imp.Metadata)
+	}
 	return a.emitServicesSelect("", "", nil)
 }
 
 func (a *api) emitServicesSelect(method string, data string, metadata headers) error {
-	if a.protofiles == nil {
+	conn := a.current()
+	if conn == nil || conn.files() == nil {
 		return nil
 	}
+	protofiles := conn.files()
 
 	var targetMd protoreflect.MethodDescriptor
 	var ss servicesSelect
-	a.protofiles.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+	protofiles.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
 		sds := fd.Services()
 		for i := 0; i < sds.Len(); i++ {
 			var s serviceSelect
 			sd := sds.Get(i)
 			s.FullName = string(sd.FullName())
+			s.Comments = descComments(sd)
+			s.Deprecated = isDeprecated(sd)
 
 			mds := sd.Methods()
 			for j := 0; j < mds.Len(); j++ {
@@ -523,6 +661,8 @@ func (a *api) emitServicesSelect(method string, data string, metadata headers) e
 					FullName:     fname,
 					ClientStream: md.IsStreamingClient(),
 					ServerStream: md.IsStreamingServer(),
+					Comments:     descComments(md),
+					Deprecated:   isDeprecated(md),
 				})
 			}
 			sort.SliceStable(s.Methods, func(i, j int) bool {
@@ -588,19 +728,43 @@ func (a *api) setMetadata(key string, hds headers) {
 	}
 }
 
-func (a *api) setMessage(method string, rawJSON []byte) {
-	opts, err := a.GetWorkspaceOptions()
-	if err != nil {
-		runtime.LogError(a.ctx, fmt.Sprintf("failed to set message, no workspace options: %v", err))
-		return
+func (a *api) setMessage(addr, method string, rawJSON []byte) {
+	if err := a.store.set([]byte(messageKeyPrefix+hash(addr, method)), rawJSON); err != nil {
+		runtime.LogError(a.ctx, fmt.Sprintf("failed to store message: %v", err))
 	}
+}
 
-	if err := a.store.set([]byte(messageKeyPrefix+hash(opts.Addr, method)), rawJSON); err != nil {
-		runtime.LogError(a.ctx, fmt.Sprintf("failed to store message: %v", err))
+func (a *api) newConnTimeline(id string) *connTimeline {
+	return newConnTimeline(a.ctx, a.store, id, func(ev connEvent) {
+		runtime.EventsEmit(a.ctx, eventConnectionEvent, ev)
+	})
+}
+
+// GetConnectionTimeline returns the recorded connectivity transitions for
+// the workspace by ID, or the current workspace if id is empty
+func (a *api) GetConnectionTimeline(id string) ([]connEvent, error) {
+	if id == "" {
+		id = a.currentID()
+	}
+	if conn := a.conns.get(id); conn != nil {
+		return conn.client.timeline.list(), nil
+	}
+	return a.newConnTimeline(id).list(), nil
+}
+
+// ClearConnectionTimeline removes all recorded connectivity transitions for
+// the workspace by ID, or the current workspace if id is empty
+func (a *api) ClearConnectionTimeline(id string) error {
+	if id == "" {
+		id = a.currentID()
 	}
+	if conn := a.conns.get(id); conn != nil {
+		return conn.client.timeline.clear()
+	}
+	return a.newConnTimeline(id).clear()
 }
 
-func (a *api) monitorStateChanges(ctx context.Context) {
+func (a *api) monitorStateChanges(ctx context.Context, conn *connection) {
 	defer func() {
 		if r := recover(); r != nil {
 			// This will panic if we are waiting for a state change and the client (and its connection)
@@ -609,28 +773,39 @@ func (a *api) monitorStateChanges(ctx context.Context) {
 		}
 	}()
 
+	c := conn.client
+	last := connectivity.State(-1)
 	for {
 		select {
 		case <-ctx.Done():
 			runtime.LogDebug(a.ctx, "ending monitoring of state changes")
 			return
 		default:
-			if a.client == nil || a.client.conn == nil {
+			cc := c.grpcConn()
+			if cc == nil {
 				// If client or connection is nil, wait a bit and check again
 				time.Sleep(500 * time.Millisecond)
 				continue
 			}
 
-			state := a.client.conn.GetState()
-			runtime.EventsEmit(a.ctx, eventClientStateChanged, state.String())
+			state := cc.GetState()
+			if conn.id == a.currentID() {
+				runtime.EventsEmit(a.ctx, eventClientStateChanged, state.String())
+			}
+			if state != last {
+				c.timeline.add(connEvent{
+					Kind:  connEventState,
+					State: state.String(),
+				})
+				last = state
+			}
 
 			timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
-			ok := a.client.conn.WaitForStateChange(timeoutCtx, state)
+			ok := cc.WaitForStateChange(timeoutCtx, state)
 			cancel()
 
-			if !ok {
+			if !ok && ctx.Err() != nil {
 				runtime.LogDebug(a.ctx, "ending monitoring of state changes")
-				time.Sleep(500 * time.Millisecond)
 				return
 			}
 		}
@@ -638,22 +813,11 @@ func (a *api) monitorStateChanges(ctx context.Context) {
 }
 
 func (a *api) getMethodDesc(fullname string) (protoreflect.MethodDescriptor, error) {
-	if a.protofiles == nil {
+	conn := a.current()
+	if conn == nil {
 		return nil, fmt.Errorf("no proto files loaded")
 	}
-
-	name := strings.Replace(fullname[1:], "/", ".", 1)
-	desc, err := a.protofiles.FindDescriptorByName(protoreflect.FullName(name))
-	if err != nil {
-		return nil, fmt.Errorf("failed to find descriptor: %v", err)
-	}
-
-	methodDesc, ok := desc.(protoreflect.MethodDescriptor)
-	if !ok {
-		return nil, fmt.Errorf("descriptor was not a method: %T", desc)
-	}
-
-	return methodDesc, nil
+	return conn.methodDesc(fullname)
 }
 
 // SelectMethod is called when the user selects a new method by the given name
@@ -702,6 +866,8 @@ func messageViewFromDesc(md protoreflect.MessageDescriptor, cd *cyclicDetector)
 	var rtn messageDesc
 	rtn.Name = string(md.Name())
 	rtn.FullName = string(md.FullName())
+	rtn.Comments = descComments(md)
+	rtn.Deprecated = isDeprecated(md)
 
 	fds := md.Fields()
 	var err error
@@ -718,6 +884,9 @@ func setFieldDescBasics(fdesc *fieldDesc, fd protoreflect.FieldDescriptor) {
 	fdesc.Kind = fd.Kind().String()
 	fdesc.FullName = string(fd.FullName())
 	fdesc.Repeated = fd.IsList()
+	fdesc.JSONName = fd.JSONName()
+	fdesc.Comments = descComments(fd)
+	fdesc.Deprecated = isDeprecated(fd)
 
 	if emd := fd.Enum(); emd != nil {
 		evals := emd.Values()
@@ -764,6 +933,9 @@ func fieldViewsFromDesc(fds protoreflect.FieldDescriptors, isOneof bool, cd *cyc
 				}
 				fdesc.Name = string(oneof.Name())
 				fdesc.Kind = "oneof"
+				fdesc.JSONName = ""
+				fdesc.Comments = descComments(oneof)
+				fdesc.Deprecated = false
 				var err error
 				fdesc.Oneof, err = fieldViewsFromDesc(oneof.Fields(), true, cd)
 				if err != nil {
@@ -805,44 +977,47 @@ func fieldViewsFromDesc(fds protoreflect.FieldDescriptors, isOneof bool, cd *cyc
 	return fields, nil
 }
 
+// RetryConnection immediately retries the connection of the current
+// workspace, if it is disconnected
 func (a *api) RetryConnection() {
-	if a.client == nil || a.client.conn == nil {
+	conn := a.current()
+	if conn == nil {
+		runtime.LogError(a.ctx, "cannot retry connection: client or connection is nil")
+		return
+	}
+	a.retryConnection(conn)
+}
+
+func (a *api) retryConnection(conn *connection) {
+	c := conn.client
+	if c != nil && c.http != nil {
+		return
+	}
+	cc := c.grpcConn()
+	if cc == nil {
 		runtime.LogError(a.ctx, "cannot retry connection: client or connection is nil")
 		return
 	}
 
-	state := a.client.conn.GetState()
+	state := cc.GetState()
 	if state == connectivity.TransientFailure || state == connectivity.Shutdown {
 		// State is currently disconnected. Do a quick retry in case the server restarted recently.
 		runtime.LogInfo(a.ctx, "connection in failed state, attempting to reset connection backoff")
-		a.client.conn.ResetConnectBackoff()
-
-		// Setup a one-time event handler to detect state change
-		stateChanged := make(chan bool, 1)
-		unsubscribe := runtime.EventsOn(a.ctx, eventClientStateChanged, func(data ...interface{}) {
-			select {
-			case stateChanged <- true:
-				// Signal sent
-			default:
-				// Channel already has a value, no need to send again
-			}
-		})
+		cc.ResetConnectBackoff()
 
 		// Wait for at least one retry to complete or timeout after 5 seconds
-		select {
-		case <-stateChanged:
-			// State changed, continue
-		case <-time.After(5 * time.Second):
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if !cc.WaitForStateChange(ctx, state) {
 			runtime.LogWarning(a.ctx, "retry connection timed out waiting for state change")
 		}
-
-		// Clean up event handler
-		unsubscribe()
 	}
 }
 
+// Send invokes the method on the current workspace and waits for the call
+// to complete. If a client stream of the method is already in flight the
+// message is sent on that stream instead.
 func (a *api) Send(method string, stringJSON string, rawHeaders interface{}) (rerr error) {
-	rawJSON := []byte(stringJSON)
 	defer func() {
 		if rerr != nil {
 			const errTitle = "Unable to send request"
@@ -851,84 +1026,212 @@ func (a *api) Send(method string, stringJSON string, rawHeaders interface{}) (re
 		}
 	}()
 
-	a.RetryConnection()
+	if c := a.activeClientStream(a.currentID(), method); c != nil {
+		return a.sendStreamMessage(c, stringJSON)
+	}
 
-	md, err := a.getMethodDesc(method)
+	c, req, err := a.prepareCall(a.currentID(), method, stringJSON, rawHeaders)
 	if err != nil {
-		const errTitle = "getMethodDesc"
-		runtime.LogError(a.ctx, err.Error())
-		runtime.EventsEmit(a.ctx, eventError, errorMsg{errTitle, err.Error()})
 		return err
 	}
+	return a.runCall(c, req)
+}
 
-	req := dynamicpb.NewMessage(md.Input())
-	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(rawJSON, req); err != nil {
+// StartCall invokes the method on the workspace, or the current workspace if
+// empty, without waiting for it to complete. The returned call ID is set on
+// every event of the call and is used to send, close or cancel it.
+func (a *api) StartCall(workspaceID, method, stringJSON string, rawHeaders interface{}) (id string, rerr error) {
+	defer func() {
+		if rerr != nil {
+			const errTitle = "Unable to send request"
+			runtime.LogError(a.ctx, rerr.Error())
+			runtime.EventsEmit(a.ctx, eventError, errorMsg{errTitle, rerr.Error()})
+		}
+	}()
+
+	if workspaceID == "" {
+		workspaceID = a.currentID()
+	}
+	c, req, err := a.prepareCall(workspaceID, method, stringJSON, rawHeaders)
+	if err != nil {
+		return "", err
+	}
+	req = a.trackCall(c, req)
+
+	go func() {
+		if err := a.runCall(c, req); err != nil {
+			const errTitle = "Unable to send request"
+			runtime.LogError(a.ctx, err.Error())
+			runtime.EventsEmit(a.ctx, eventError, errorMsg{errTitle, err.Error()}, c.id)
+		}
+	}()
+	return c.id, nil
+}
+
+// SendStream sends a message on an in-flight client or bidirectional stream
+func (a *api) SendStream(callID string, stringJSON string) error {
+	c := a.getCall(callID)
+	if c == nil {
+		return errCallNotFound
+	}
+	return a.sendStreamMessage(c, stringJSON)
+}
+
+// ListCalls returns all the in-flight calls
+func (a *api) ListCalls() []callInfo {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	var infos []callInfo
+	for _, c := range a.calls {
+		infos = append(infos, c.info())
+	}
+	sort.SliceStable(infos, func(i, j int) bool {
+		return infos[i].Started < infos[j].Started
+	})
+	return infos
+}
+
+func (a *api) sendStreamMessage(c *call, stringJSON string) error {
+	if !c.desc.IsStreamingClient() {
+		return fmt.Errorf("method %s is not a client stream", c.method)
+	}
+	req, err := c.conn.opts.requestFormat().decode(c.desc.Input(), []byte(stringJSON))
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal request: %v", err)
+	}
+	if err := a.validateRequest(c.conn, c.method, req); err != nil {
+		return err
+	}
+	go a.setMessage(c.conn.opts.Addr, c.method, []byte(stringJSON))
+	return c.send(req)
+}
+
+// prepareCall decodes the request and metadata for a call on the workspace
+func (a *api) prepareCall(workspaceID, method, stringJSON string, rawHeaders interface{}) (*call, proto.Message, error) {
+	rawJSON := []byte(stringJSON)
+
+	c, err := a.openCall(workspaceID, method, rawHeaders)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := c.conn.opts.requestFormat().decode(c.desc.Input(), rawJSON)
+	if err != nil {
 		const errTitle = "unmarshal"
 		runtime.LogError(a.ctx, err.Error())
 		runtime.EventsEmit(a.ctx, eventError, errorMsg{errTitle, err.Error()})
-		return fmt.Errorf("failed to unmarshal request: %v", err)
+		return nil, nil, fmt.Errorf("failed to unmarshal request: %v", err)
 	}
 
 	// Store message for later use
-	go a.setMessage(method, rawJSON)
+	go a.setMessage(c.conn.opts.Addr, method, rawJSON)
 
-	if a.inFlight && md.IsStreamingClient() {
-		a.streamReq <- req
-		return nil
+	if err := a.validateRequest(c.conn, method, req); err != nil {
+		c.cancel()
+		return nil, nil, err
 	}
 
-	a.mu.Lock()
-	a.inFlight = true
-	defer func() {
-		a.mu.Unlock()
-		a.inFlight = false
-	}()
-
-	ctx := metadata.NewOutgoingContext(context.Background(), metadata.New(nil))
+	return c, req, nil
+}
 
-	var hs headers
-	if err := mapstructure.Decode(rawHeaders, &hs); err != nil {
-		return fmt.Errorf("failed to decode headers: %v", err)
+// openCall creates the call for the method on the workspace, with the
+// headers as its outgoing metadata
+func (a *api) openCall(workspaceID, method string, rawHeaders interface{}) (*call, error) {
+	conn := a.conns.get(workspaceID)
+	if conn == nil {
+		return nil, fmt.Errorf("workspace %q is not connected", workspaceID)
 	}
 
-	opts, err := a.GetWorkspaceOptions()
+	a.retryConnection(conn)
+
+	md, err := conn.methodDesc(method)
 	if err != nil {
-		return err
+		const errTitle = "getMethodDesc"
+		runtime.LogError(a.ctx, err.Error())
+		runtime.EventsEmit(a.ctx, eventError, errorMsg{errTitle, err.Error()})
+		return nil, err
+	}
+
+	var hs headers
+	if err := mapstructure.Decode(rawHeaders, &hs); err != nil {
+		return nil, fmt.Errorf("failed to decode headers: %v", err)
 	}
-	go a.setMetadata(metadataKeyPrefix+hash(opts.Addr), hs)
+	go a.setMetadata(metadataKeyPrefix+hash(conn.opts.Addr), hs)
 
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.New(nil))
 	for _, h := range hs {
 		if h.Key == "" {
 			continue
 		}
 		ctx = metadata.AppendToOutgoingContext(ctx, h.Key, h.Val)
 	}
+	ctx = context.WithValue(ctx, ctxAttemptsKey{}, &callAttempts{})
+	rec := newRecorder(conn.opts.Addr, method, md)
+	ctx = context.WithValue(ctx, ctxRecorderKey{}, rec)
 
-	ctx, a.cancelInFlight = context.WithCancel(ctx)
+	c := newCall(ctx, conn, method, md)
+	rec.entry.CallID = c.id
+	return c, nil
+}
+
+// trackCall makes the call findable by its ID, so it can be sent to, closed
+// or cancelled. The request of a client stream is queued first, so it is
+// always the first message, and nil is returned in its place. It is called
+// by runCall, and before it by anything that returns the ID of a call it
+// runs in the background.
+func (a *api) trackCall(c *call, req proto.Message) proto.Message {
+	if req != nil && c.desc.IsStreamingClient() {
+		c.reqs <- req
+		req = nil
+	}
+	a.addCall(c)
+	return req
+}
+
+// runCall invokes the call and blocks until it completes. The request may be
+// nil for client and bidirectional streams, which then wait for the first
+// message to be sent.
+func (a *api) runCall(c *call, req proto.Message) (rerr error) {
+	req = a.trackCall(c, req)
+	defer func() {
+		if ca := attemptsFromContext(c.ctx); ca != nil && ca.finish(rerr) {
+			a.callEnded(c.ctx, ca)
+		}
+		close(c.done)
+		c.closeSend()
+		c.cancel()
+		a.removeCall(c)
+	}()
+
+	md := c.desc
+	client := c.conn.client
+	ctx := c.ctx
 
 	runtime.EventsEmit(a.ctx, eventRPCStarted, rpcStart{
 		ClientStream: md.IsStreamingClient(),
 		ServerStream: md.IsStreamingServer(),
+		CallID:       c.id,
 	})
 
 	if md.IsStreamingClient() && md.IsStreamingServer() {
-		stream, err := a.client.invokeBidiStream(ctx, method)
+		stream, err := client.invokeBidiStream(ctx, c.method)
 		if err != nil {
-			return fmt.Errorf("failed to invoke bidirectional stream: %v", err)
+			return fmt.Errorf("failed to invoke bidirectional stream: %w", err)
 		}
 
-		a.streamReq = make(chan proto.Message)
 		go func() {
-			for r := range a.streamReq {
+			failed := false
+			for r := range c.reqs {
+				if failed {
+					continue
+				}
 				if err := stream.SendMsg(r); err != nil {
 					runtime.LogError(a.ctx, fmt.Sprintf("failed to send message to stream: %v", err))
-					close(a.streamReq)
-					a.streamReq = nil
+					failed = true
 				}
 			}
 			stream.CloseSend()
 		}()
-		a.streamReq <- req
 
 		for {
 			resp := dynamicpb.NewMessage(md.Output())
@@ -938,34 +1241,30 @@ func (a *api) Send(method string, stringJSON string, rawHeaders interface{}) (re
 				}
 				break
 			}
+			c.received(resp)
 		}
 
 		return nil
 	}
 
 	if md.IsStreamingClient() {
-		stream, err := a.client.invokeClientStream(ctx, method)
+		stream, err := client.invokeClientStream(ctx, c.method)
 		if err != nil {
-			return fmt.Errorf("failed to invoke client stream: %v", err)
+			return fmt.Errorf("failed to invoke client stream: %w", err)
 		}
-		a.streamReq = make(chan proto.Message, 1)
-		a.streamReq <- req
 		done := ctx.Done()
 
 	wait:
 		for {
 			select {
 			case <-done:
-				a.CloseSend()
 				return nil
-			case r := <-a.streamReq:
-				if r == nil {
+			case r, ok := <-c.reqs:
+				if !ok {
 					break wait
 				}
 				if err := stream.SendMsg(r); err != nil {
 					runtime.LogError(a.ctx, fmt.Sprintf("failed to send message to stream: %v", err))
-					close(a.streamReq)
-					a.streamReq = nil
 					break wait
 				}
 			}
@@ -976,6 +1275,8 @@ func (a *api) Send(method string, stringJSON string, rawHeaders interface{}) (re
 			if err != io.EOF {
 				return fmt.Errorf("error receiving message: %v", err)
 			}
+		} else {
+			c.received(resp)
 		}
 		if err := stream.RecvMsg(nil); err != io.EOF {
 			runtime.LogWarning(a.ctx, fmt.Sprintf("unexpected message received after EOF: %v", err))
@@ -985,9 +1286,9 @@ func (a *api) Send(method string, stringJSON string, rawHeaders interface{}) (re
 	}
 
 	if md.IsStreamingServer() {
-		stream, err := a.client.invokeServerStream(ctx, method, req)
+		stream, err := client.invokeServerStream(ctx, c.method, req)
 		if err != nil {
-			return fmt.Errorf("failed to invoke server stream: %v", err)
+			return fmt.Errorf("failed to invoke server stream: %w", err)
 		}
 		for {
 			resp := dynamicpb.NewMessage(md.Output())
@@ -997,6 +1298,7 @@ func (a *api) Send(method string, stringJSON string, rawHeaders interface{}) (re
 				}
 				break
 			}
+			c.received(resp)
 		}
 
 		return nil
@@ -1004,21 +1306,40 @@ func (a *api) Send(method string, stringJSON string, rawHeaders interface{}) (re
 
 	// Standard unary call
 	resp := dynamicpb.NewMessage(md.Output())
-	if err := a.client.invoke(ctx, method, req, resp); err != nil {
-		return fmt.Errorf("failed to invoke RPC: %v", err)
+	if err := client.invoke(ctx, c.method, req, resp); err != nil {
+		return fmt.Errorf("failed to invoke RPC: %w", err)
 	}
+	c.received(resp)
 	return nil
 }
 
 // TagConn implements the stats.Handler interface
-func (statsHandler) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
-	// noop
-	return ctx
+func (statsHandler) TagConn(ctx context.Context, info *stats.ConnTagInfo) context.Context {
+	return context.WithValue(ctx, ctxConnKey{}, info)
 }
 
 // HandleConn implements the stats.Handler interface
-func (statsHandler) HandleConn(context.Context, stats.ConnStats) {
-	// noop
+func (a statsHandler) HandleConn(ctx context.Context, stat stats.ConnStats) {
+	var ev connEvent
+	switch stat.(type) {
+	case *stats.ConnBegin:
+		ev.Kind = connEventBegin
+		ev.Reason = "transport established"
+	case *stats.ConnEnd:
+		ev.Kind = connEventEnd
+		ev.Reason = "transport closed"
+	default:
+		return
+	}
+	if info, ok := ctx.Value(ctxConnKey{}).(*stats.ConnTagInfo); ok {
+		if info.RemoteAddr != nil {
+			ev.RemoteAddr = info.RemoteAddr.String()
+		}
+		if info.LocalAddr != nil {
+			ev.LocalAddr = info.LocalAddr.String()
+		}
+	}
+	a.timeline.add(ev)
 }
 
 // TagRPC implements the stats.Handler interface
@@ -1033,22 +1354,35 @@ func (a statsHandler) HandleRPC(ctx context.Context, stat stats.RPCStats) {
 		return
 	}
 
+	// The call ID is sent as the last argument of events that don't
+	// carry a struct, so it is optional for listeners
+	callID := callIDFromContext(ctx)
+
+	// Recorded before the payloads are formatted for the frontend
+	if r := recorderFromContext(ctx); r != nil {
+		r.handle(stat)
+	}
+
 	switch s := stat.(type) {
 	case *stats.Begin:
-		runtime.EventsEmit(a.ctx, eventStatBegin, s)
+		var attempt int
+		if ca := attemptsFromContext(ctx); ca != nil {
+			attempt = ca.begin(s)
+		}
+		runtime.EventsEmit(a.ctx, eventStatBegin, rpcStatBegin{s, attempt, callID})
 	case *stats.OutHeader:
-		runtime.EventsEmit(a.ctx, eventStatOutHeader, rpcStatOutHeader{s, fmt.Sprintf("%+v", s.Header)})
+		runtime.EventsEmit(a.ctx, eventStatOutHeader, rpcStatOutHeader{s, fmt.Sprintf("%+v", s.Header), callID})
 	case *stats.OutPayload:
 		if p, err := formatPayload(s.Payload); err == nil {
 			s.Payload = p
 		}
-		runtime.EventsEmit(a.ctx, eventStatOutPayload, rpcStatOutPayload{s, fmt.Sprintf("%+v", s.Payload)})
-		runtime.EventsEmit(a.ctx, eventOutPayloadReceived, s.Payload)
+		runtime.EventsEmit(a.ctx, eventStatOutPayload, rpcStatOutPayload{s, fmt.Sprintf("%+v", s.Payload), callID})
+		runtime.EventsEmit(a.ctx, eventOutPayloadReceived, s.Payload, callID)
 	case *stats.OutTrailer:
-		runtime.EventsEmit(a.ctx, eventStatOutTrailer, rpcStatOutTrailer{s, fmt.Sprintf("%+v", s.Trailer)})
+		runtime.EventsEmit(a.ctx, eventStatOutTrailer, rpcStatOutTrailer{s, fmt.Sprintf("%+v", s.Trailer), callID})
 	case *stats.InHeader:
-		runtime.EventsEmit(a.ctx, eventStatInHeader, rpcStatInHeader{s, fmt.Sprintf("%+v", s.Header)})
-		runtime.EventsEmit(a.ctx, eventInHeaderReceived, s.Header)
+		runtime.EventsEmit(a.ctx, eventStatInHeader, rpcStatInHeader{s, fmt.Sprintf("%+v", s.Header), callID})
+		runtime.EventsEmit(a.ctx, eventInHeaderReceived, s.Header, callID)
 	case *stats.InPayload:
 		txt, err := formatPayload(s.Payload)
 		if err != nil {
@@ -1056,33 +1390,62 @@ func (a statsHandler) HandleRPC(ctx context.Context, stat stats.RPCStats) {
 			return
 		}
 		s.Payload = txt
-		runtime.EventsEmit(a.ctx, eventStatInPayload, rpcStatInPayload{s, fmt.Sprintf("%+v", s.Payload)})
-		runtime.EventsEmit(a.ctx, eventInPayloadReceived, txt)
+		runtime.EventsEmit(a.ctx, eventStatInPayload, rpcStatInPayload{s, fmt.Sprintf("%+v", s.Payload), callID})
+		runtime.EventsEmit(a.ctx, eventInPayloadReceived, txt, callID)
 	case *stats.InTrailer:
-		runtime.EventsEmit(a.ctx, eventStatInTrailer, rpcStatInTrailer{s, fmt.Sprintf("%+v", s.Trailer)})
-		runtime.EventsEmit(a.ctx, eventInTrailerReceived, s.Trailer)
+		runtime.EventsEmit(a.ctx, eventStatInTrailer, rpcStatInTrailer{s, fmt.Sprintf("%+v", s.Trailer), callID})
+		runtime.EventsEmit(a.ctx, eventInTrailerReceived, s.Trailer, callID)
 	case *stats.End:
+		errProtoStr, err := formatStatus(s.Error)
+		if err != nil {
+			runtime.LogError(a.ctx, fmt.Errorf("failed to marshal in payload to proto text: %v", err).Error())
+		}
+		runtime.EventsEmit(a.ctx, eventStatEnd, rpcStatEnd{s, errProtoStr, callID})
 
-		errProtoStr := ""
-		stus := status.Convert(s.Error)
-		if stus != nil {
-			var err error
-			errProtoStr, err = formatPayload(stus.Proto())
-			if err != nil {
-				runtime.LogError(a.ctx, fmt.Errorf("failed to marshal in payload to proto text: %v", err).Error())
-			}
-			if errProtoStr != "" {
-				runtime.EventsEmit(a.ctx, eventErrorReceived, errProtoStr)
-			}
+		if ca := attemptsFromContext(ctx); ca != nil && ca.end(s) {
+			a.callEnded(ctx, ca)
 		}
-		runtime.EventsEmit(a.ctx, eventStatEnd, rpcStatEnd{s, errProtoStr})
+	}
+}
 
-		var end rpcEnd
-		end.StatusCode = int32(stus.Code())
-		end.Status = stus.Code().String()
-		end.Duration = s.EndTime.Sub(s.BeginTime).String()
-		runtime.EventsEmit(a.ctx, eventRPCEnded, end)
+// callEnded emits the end of the call and saves it to the history. It is
+// called once per call, with the end of its last attempt, as grpc reports
+// the end of every attempt of retried and hedged calls.
+func (a *api) callEnded(ctx context.Context, ca *callAttempts) {
+	callID := callIDFromContext(ctx)
+	end, d := ca.result()
+	attempts, transparent := ca.count()
+	if r := recorderFromContext(ctx); r != nil {
+		if attempts == 0 {
+			// No attempt was started, so the recorder has seen nothing
+			r.handle(&stats.Begin{Client: true, BeginTime: end.BeginTime})
+			r.handle(end)
+		}
+		go a.saveHistory(r.snapshot())
 	}
+
+	errProtoStr, _ := formatStatus(end.Error)
+	if errProtoStr != "" {
+		runtime.EventsEmit(a.ctx, eventErrorReceived, errProtoStr, callID)
+	}
+	stus := status.Convert(end.Error)
+	runtime.EventsEmit(a.ctx, eventRPCEnded, rpcEnd{
+		CallID:             callID,
+		StatusCode:         int32(stus.Code()),
+		Status:             stus.Code().String(),
+		Duration:           d.String(),
+		Attempts:           attempts,
+		TransparentRetries: transparent,
+	})
+}
+
+// formatStatus returns the status of the error in the proto text format
+func formatStatus(err error) (string, error) {
+	stus := status.Convert(err)
+	if stus == nil {
+		return "", nil
+	}
+	return formatPayload(stus.Proto())
 }
 
 func formatPayload(payload interface{}) (string, error) {
@@ -1108,40 +1471,73 @@ func formatPayload(payload interface{}) (string, error) {
 	return string(b), nil
 }
 
-// CloseSend will stop streaming client messages
+// CloseSend will stop streaming client messages of the most recent call
 func (a *api) CloseSend() {
-	if a.streamReq != nil {
-		runtime.LogDebug(a.ctx, "closing stream request channel")
-		close(a.streamReq)
-		a.streamReq = nil
-	} else {
-		runtime.LogDebug(a.ctx, "streamReq already closed or nil")
+	a.CloseSendCall("")
+}
+
+// CloseSendCall will stop streaming client messages of the call by ID
+func (a *api) CloseSendCall(id string) {
+	c := a.getCall(id)
+	if c == nil {
+		runtime.LogDebug(a.ctx, "no in-flight call to close")
+		return
 	}
+	runtime.LogDebug(a.ctx, "closing stream request channel")
+	c.closeSend()
 }
 
-// Cancel will attempt to cancel the current inflight request
+// Cancel will attempt to cancel the most recent inflight request
 func (a *api) Cancel() {
-	if a.cancelInFlight != nil {
-		runtime.LogDebug(a.ctx, "cancelling in-flight request")
-		a.cancelInFlight()
-		// Signal to frontend that the request was cancelled
-		runtime.EventsEmit(a.ctx, eventRPCEnded, rpcEnd{
-			StatusCode: int32(codes.Canceled),
-			Status:     codes.Canceled.String(),
-			Duration:   "0s",
-		})
-	} else {
+	a.CancelCall("")
+}
+
+// CancelCall will attempt to cancel the inflight request by call ID
+func (a *api) CancelCall(id string) {
+	c := a.getCall(id)
+	if c == nil {
 		runtime.LogDebug(a.ctx, "no in-flight request to cancel")
+		return
+	}
+	runtime.LogDebug(a.ctx, "cancelling in-flight request")
+	// The call ends with a Canceled rpc_ended once its attempts have ended
+	c.cancel()
+}
+
+// grpcurlData returns the request body as grpcurl reads it, which is JSON
+// or the text format with -format text, along with the format
+func (a *api) grpcurlData(method string, body []byte) ([]byte, string) {
+	conn := a.current()
+	if conn == nil {
+		return body, formatJSON
+	}
+	f := conn.opts.requestFormat()
+	if f.isJSON() || f.format == formatText {
+		return body, f.format
+	}
+	md, err := conn.methodDesc(method)
+	if err != nil {
+		return body, formatJSON
+	}
+	data, err := f.requestJSON(md.Input(), body)
+	if err != nil {
+		runtime.LogWarning(a.ctx, fmt.Sprintf("failed to convert request to JSON: %v", err))
+		return body, formatJSON
 	}
+	return data, formatJSON
 }
 
 // Export commands for call
 func (a *api) ExportCommands(method string, stringJSON string, rawHeaders interface{}) *commands {
 	rawJSON := []byte(stringJSON)
+	data, format := a.grpcurlData(method, rawJSON)
 	var sb strings.Builder
 	sb.WriteString("grpcurl ")
+	if format == formatText {
+		sb.WriteString("-format text ")
+	}
 	sb.WriteString("-d '")
-	sb.Write(rawJSON)
+	sb.Write(data)
 	sb.WriteString("' \\\n")
 
 	var hs headers
@@ -1177,6 +1573,15 @@ func (a *api) ExportCommands(method string, stringJSON string, rawHeaders interf
 	if option.Insecure {
 		sb.WriteString("    -insecure \\\n")
 	}
+	if option.ServerName != "" {
+		fmt.Fprintf(&sb, "    -servername '%s' \\\n", option.ServerName)
+	}
+	if option.Authority != "" {
+		fmt.Fprintf(&sb, "    -authority '%s' \\\n", option.Authority)
+	}
+	if option.Timeout > 0 {
+		fmt.Fprintf(&sb, "    -max-time %g \\\n", option.Timeout)
+	}
 
 	hds, err := a.GetReflectMetadata(option.Addr)
 	if err != nil {
@@ -1199,12 +1604,32 @@ func (a *api) ExportCommands(method string, stringJSON string, rawHeaders interf
 	sb.WriteString(" ")
 	sb.WriteString(method[1:])
 
-	return &commands{
-		Grpcurl: sb.String(),
+	cmds := &commands{Grpcurl: sb.String()}
+	conn := a.current()
+	if conn == nil {
+		cmds.Curl = "Error: " + errNoConn.Error()
+		return cmds
+	}
+	md, err := conn.methodDesc(method)
+	if err != nil {
+		cmds.Curl = "Error: " + err.Error()
+		return cmds
 	}
+
+	if cmds.Curl, err = curlCommand(*option, md, method, rawJSON, hs); err != nil {
+		runtime.LogError(a.ctx, fmt.Sprintf("failed to export curl command: %v", err))
+		cmds.Curl = "Error: " + err.Error()
+	}
+	if cmds.Snippets, err = codeSnippets(*option, md, rawJSON, hs); err != nil {
+		runtime.LogWarning(a.ctx, fmt.Sprintf("failed to generate code snippets: %v", err))
+	}
+	return cmds
 }
 
-func (a *api) ImportCommand(kind string, command string) (rerr error) {
+// ImportCommand imports a grpcurl or curl command as a workspace and a call.
+// The workspace with the same address is updated, or else a new one is
+// created, and then connected. The method of the call is selected once its proto files load.
+func (a *api) ImportCommand(kind string, command string) (imported *importedCommand, rerr error) {
 	defer func() {
 		if rerr != nil {
 			const errTitle = "Failed to import command"
@@ -1215,16 +1640,25 @@ func (a *api) ImportCommand(kind string, command string) (rerr error) {
 
 	switch strings.ToLower(kind) {
 	case "grpcurl":
-		args, err := parseGrpcurlCommand(command)
-		if err != nil {
-			return fmt.Errorf("error parsing grpcurl command: %v", err)
+		var err error
+		if imported, err = a.importGrpcurl(command); err != nil {
+			return nil, err
+		}
+	case "curl":
+		var err error
+		if imported, err = a.importCurl(command); err != nil {
+			return nil, err
 		}
-
-		runtime.LogInfo(a.ctx, fmt.Sprintf("importing grpcurl command for method: %s", args.Method))
-		return a.emitServicesSelect("/"+args.Method, args.Data, args.Metadata)
 	default:
-		return fmt.Errorf("unsupported command type: %s", kind)
+		return nil, fmt.Errorf("unsupported command type: %s", kind)
+	}
+
+	runtime.LogInfo(a.ctx, fmt.Sprintf("importing %s command for method: %s", kind, imported.Method))
+	// Connection errors are already reported by connect
+	if err := a.connect(imported.Workspace, imported.ReflectMetadata, true, imported); err == nil {
+		imported.Workspace.ID = a.currentID()
 	}
+	return imported, nil
 }
 
 func (a *api) GetWindowInfo() map[string]interface{} {
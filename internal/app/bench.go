@@ -0,0 +1,557 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+const (
+	defaultBenchTotal       = 200
+	defaultBenchConcurrency = 10
+	benchProgressInterval   = 500 * time.Millisecond
+	benchHistogramBuckets   = 10
+	// maxBenchQPS is the highest rate the requests can be paced at, with a
+	// tick every microsecond
+	maxBenchQPS = 1e6
+	// minBenchQPS is the lowest rate, with a tick every 1000s, keeping the
+	// ticker interval well within a time.Duration
+	minBenchQPS = 1e-3
+)
+
+var benchPercentiles = []float64{10, 25, 50, 75, 90, 95, 99}
+
+// benchOptions are the settings of a benchmark run. If neither Total nor
+// Duration is set, defaultBenchTotal requests are sent.
+type benchOptions struct {
+	Total       int     `json:"total"`
+	Duration    float64 `json:"duration"` // seconds
+	Concurrency int     `json:"concurrency"`
+	QPS         float64 `json:"qps"`
+	Connections int     `json:"connections"`
+}
+
+type benchProgress struct {
+	ID        string  `json:"id"`
+	Completed int     `json:"completed"`
+	Errors    int     `json:"errors"`
+	Elapsed   float64 `json:"elapsed_ms"`
+	RPS       float64 `json:"rps"`
+}
+
+type benchPercentile struct {
+	Percentile float64 `json:"percentile"`
+	Latency    float64 `json:"latency_ms"`
+}
+
+type benchBucket struct {
+	Mark      float64 `json:"mark_ms"`
+	Count     int     `json:"count"`
+	Frequency float64 `json:"frequency"`
+}
+
+// benchResult is the summary of a benchmark run, as saved to the store.
+// All latencies are in milliseconds.
+type benchResult struct {
+	ID          string            `json:"id"`
+	Addr        string            `json:"addr"`
+	Method      string            `json:"method"`
+	Options     benchOptions      `json:"options"`
+	Started     time.Time         `json:"started"`
+	Elapsed     float64           `json:"elapsed_ms"`
+	Count       int               `json:"count"`
+	Errors      int               `json:"errors"`
+	RPS         float64           `json:"rps"`
+	Fastest     float64           `json:"fastest_ms"`
+	Slowest     float64           `json:"slowest_ms"`
+	Average     float64           `json:"average_ms"`
+	Percentiles []benchPercentile `json:"percentiles"`
+	Histogram   []benchBucket     `json:"histogram"`
+	StatusCodes map[string]int    `json:"status_codes"`
+	Cancelled   bool              `json:"cancelled"`
+}
+
+// benchCallData is available to body templates, so each request can be
+// different, e.g. {"id": "{{.UUID}}", "seq": {{.RequestNumber}}}
+type benchCallData struct {
+	RequestNumber int64
+	WorkerID      int
+	Timestamp     string
+	UnixNano      int64
+	UUID          string
+}
+
+// benchmark sends the method repeatedly over dedicated connections, so the
+// calls don't flood the frontend with stats events.
+type benchmark struct {
+	id      string
+	opts    benchOptions
+	method  string
+	desc    protoreflect.MethodDescriptor
+	ctx     context.Context // with the metadata to send
+	body    []byte
+	format  requestFormat
+	tmpl    *template.Template
+	clients []*client
+	// validator checks each request, unless the workspace skips validation
+	validator *validator
+
+	mu        sync.Mutex
+	count     int
+	errors    int
+	codes     map[string]int
+	latencies []time.Duration // of the requests that were sent
+}
+
+func benchKey(addr, method, id string) []byte {
+	return []byte(benchKeyPrefix + hash(addr, method) + "_" + id)
+}
+
+func (o *benchOptions) setDefaults() error {
+	if o.Total < 0 || o.Duration < 0 || o.Concurrency < 0 || o.QPS < 0 || o.Connections < 0 {
+		return errors.New("benchmark options must not be negative")
+	}
+	if o.QPS > maxBenchQPS {
+		return fmt.Errorf("benchmark qps must not be over %d", int(maxBenchQPS))
+	}
+	if o.QPS > 0 && o.QPS < minBenchQPS {
+		return fmt.Errorf("benchmark qps must be at least %g", minBenchQPS)
+	}
+	if o.Total == 0 && o.Duration == 0 {
+		o.Total = defaultBenchTotal
+	}
+	if o.Concurrency == 0 {
+		o.Concurrency = defaultBenchConcurrency
+	}
+	if o.Total > 0 && o.Concurrency > o.Total {
+		o.Concurrency = o.Total
+	}
+	if o.Connections == 0 {
+		o.Connections = 1
+	}
+	if o.Connections > o.Concurrency {
+		o.Connections = o.Concurrency
+	}
+	return nil
+}
+
+// message returns the request for the request number, rendering the body
+// template if there is one
+func (b *benchmark) message(n int64, worker int) (proto.Message, error) {
+	body := b.body
+	if b.tmpl != nil {
+		now := time.Now()
+		var buf bytes.Buffer
+		err := b.tmpl.Execute(&buf, benchCallData{
+			RequestNumber: n,
+			WorkerID:      worker,
+			Timestamp:     now.Format(time.RFC3339Nano),
+			UnixNano:      now.UnixNano(),
+			UUID:          uuid.Must(uuid.NewV4()).String(),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute body template: %v", err)
+		}
+		body = buf.Bytes()
+	}
+	req, err := b.format.decode(b.desc.Input(), body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal request: %v", err)
+	}
+	if b.validator != nil {
+		if vs := b.validator.validate(req.ProtoReflect()); len(vs) > 0 {
+			return nil, errInvalidRequest(vs)
+		}
+	}
+	return req, nil
+}
+
+// call sends a single request, reading every response of a server or
+// bidirectional stream
+func (b *benchmark) call(ctx context.Context, c *client, req proto.Message) error {
+	md := b.desc
+	if !md.IsStreamingClient() && !md.IsStreamingServer() {
+		return c.invoke(ctx, b.method, req, dynamicpb.NewMessage(md.Output()))
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.newStream(ctx, streamDesc(b.method, md), b.method)
+	if err != nil {
+		return err
+	}
+	if err := stream.SendMsg(req); err != nil && err != io.EOF {
+		return err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return err
+	}
+	for {
+		if err := stream.RecvMsg(dynamicpb.NewMessage(md.Output())); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+func (b *benchmark) record(d time.Duration, err error, sent bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.count++
+	b.codes[status.Code(err).String()]++
+	if err != nil {
+		b.errors++
+	}
+	if sent {
+		b.latencies = append(b.latencies, d)
+	}
+}
+
+func (b *benchmark) progress(elapsed time.Duration) benchProgress {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	p := benchProgress{
+		ID:        b.id,
+		Completed: b.count,
+		Errors:    b.errors,
+		Elapsed:   ms(elapsed),
+	}
+	if elapsed > 0 {
+		p.RPS = float64(p.Completed) / elapsed.Seconds()
+	}
+	return p
+}
+
+// run sends the requests until the total is reached, the duration has
+// elapsed or ctx is cancelled
+func (b *benchmark) run(ctx context.Context, emit func(benchProgress)) *benchResult {
+	started := time.Now()
+
+	runCtx := ctx
+	if b.opts.Duration > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(ctx, seconds(b.opts.Duration))
+		defer cancel()
+	}
+
+	jobs := make(chan int64)
+	go func() {
+		defer close(jobs)
+		var tick <-chan time.Time
+		if b.opts.QPS > 0 {
+			t := time.NewTicker(time.Duration(float64(time.Second) / b.opts.QPS))
+			defer t.Stop()
+			tick = t.C
+		}
+		for n := int64(0); b.opts.Total == 0 || n < int64(b.opts.Total); n++ {
+			if tick != nil {
+				select {
+				case <-tick:
+				case <-runCtx.Done():
+					return
+				}
+			}
+			select {
+			case jobs <- n:
+			case <-runCtx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < b.opts.Concurrency; i++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			c := b.clients[worker%len(b.clients)]
+			for n := range jobs {
+				req, err := b.message(n, worker)
+				if err != nil {
+					b.record(0, status.Error(codes.InvalidArgument, err.Error()), false)
+					continue
+				}
+				start := time.Now()
+				err = b.call(metadataContext(runCtx, b.ctx), c, req)
+				if runCtx.Err() != nil && err != nil {
+					// Calls cut short by the end of the run aren't counted
+					return
+				}
+				b.record(time.Since(start), err, true)
+			}
+		}(i)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	ticker := time.NewTicker(benchProgressInterval)
+	defer ticker.Stop()
+wait:
+	for {
+		select {
+		case <-ticker.C:
+			emit(b.progress(time.Since(started)))
+		case <-done:
+			break wait
+		}
+	}
+
+	elapsed := time.Since(started)
+	emit(b.progress(elapsed))
+	res := b.result(started, elapsed)
+	res.Cancelled = ctx.Err() != nil
+	return res
+}
+
+func (b *benchmark) result(started time.Time, elapsed time.Duration) *benchResult {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	res := &benchResult{
+		ID:          b.id,
+		Method:      b.method,
+		Options:     b.opts,
+		Started:     started,
+		Elapsed:     ms(elapsed),
+		Count:       b.count,
+		Errors:      b.errors,
+		StatusCodes: b.codes,
+	}
+	if elapsed > 0 {
+		res.RPS = float64(res.Count) / elapsed.Seconds()
+	}
+	if len(b.latencies) == 0 {
+		return res
+	}
+
+	lats := append([]time.Duration(nil), b.latencies...)
+	sort.Slice(lats, func(i, j int) bool { return lats[i] < lats[j] })
+
+	var total time.Duration
+	for _, l := range lats {
+		total += l
+	}
+	fastest, slowest := lats[0], lats[len(lats)-1]
+	res.Fastest = ms(fastest)
+	res.Slowest = ms(slowest)
+	res.Average = ms(total / time.Duration(len(lats)))
+
+	for _, p := range benchPercentiles {
+		i := int(math.Ceil(p/100*float64(len(lats)))) - 1
+		if i < 0 {
+			i = 0
+		}
+		res.Percentiles = append(res.Percentiles, benchPercentile{p, ms(lats[i])})
+	}
+
+	// Each bucket counts the latencies up to its mark, starting from the
+	// fastest and ending with the slowest
+	step := (slowest - fastest) / benchHistogramBuckets
+	i := 0
+	for n := 1; n <= benchHistogramBuckets; n++ {
+		mark := fastest + step*time.Duration(n)
+		if n == benchHistogramBuckets {
+			mark = slowest
+		}
+		count := 0
+		for i < len(lats) && lats[i] <= mark {
+			count++
+			i++
+		}
+		res.Histogram = append(res.Histogram, benchBucket{
+			Mark:      ms(mark),
+			Count:     count,
+			Frequency: float64(count) / float64(len(lats)),
+		})
+	}
+	return res
+}
+
+func (b *benchmark) close() {
+	for _, c := range b.clients {
+		c.close()
+	}
+}
+
+// metadataContext returns ctx with the outgoing metadata of md
+func metadataContext(ctx, md context.Context) context.Context {
+	out, _ := metadata.FromOutgoingContext(md)
+	return metadata.NewOutgoingContext(ctx, out)
+}
+
+func ms(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// StartBenchmark load tests the method of the current workspace with the
+// request body, which may be a template, and metadata. Progress and the
+// result are sent as events and it returns the ID of the run.
+func (a *api) StartBenchmark(method, body string, rawHeaders interface{}, rawOpts interface{}) (id string, rerr error) {
+	defer func() {
+		if rerr != nil {
+			const errTitle = "Unable to start benchmark"
+			runtime.LogError(a.ctx, rerr.Error())
+			a.emitError(errTitle, rerr.Error())
+		}
+	}()
+
+	var opts benchOptions
+	if err := mapstructure.Decode(rawOpts, &opts); err != nil {
+		return "", fmt.Errorf("failed to decode benchmark options: %v", err)
+	}
+	if err := opts.setDefaults(); err != nil {
+		return "", err
+	}
+
+	conn := a.current()
+	if conn == nil {
+		return "", errNoConn
+	}
+	md, err := conn.methodDesc(method)
+	if err != nil {
+		return "", err
+	}
+
+	var hs headers
+	if err := mapstructure.Decode(rawHeaders, &hs); err != nil {
+		return "", fmt.Errorf("failed to decode headers: %v", err)
+	}
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.New(nil))
+	for _, h := range hs {
+		if h.Key == "" {
+			continue
+		}
+		ctx = metadata.AppendToOutgoingContext(ctx, h.Key, h.Val)
+	}
+
+	b := &benchmark{
+		id:     fmt.Sprintf("%020d", time.Now().UnixNano()),
+		opts:   opts,
+		method: method,
+		desc:   md,
+		ctx:    ctx,
+		body:   []byte(body),
+		format: conn.opts.requestFormat(),
+		codes:  make(map[string]int),
+	}
+	if !conn.opts.SkipValidation {
+		b.validator = conn.requestValidator()
+	}
+	if strings.Contains(body, "{{") {
+		if b.tmpl, err = template.New("body").Option("missingkey=error").Parse(body); err != nil {
+			return "", fmt.Errorf("invalid body template: %v", err)
+		}
+	}
+	// Check the body before connecting, so mistakes are reported straight away
+	if _, err := b.message(0, 0); err != nil {
+		return "", err
+	}
+
+	for i := 0; i < opts.Connections; i++ {
+		c := &client{}
+		if err := c.connect(conn.opts, nil); err != nil {
+			b.close()
+			c.close()
+			return "", fmt.Errorf("failed to connect to server: %v", err)
+		}
+		b.clients = append(b.clients, c)
+	}
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	a.mu.Lock()
+	a.benchmarks[b.id] = cancel
+	a.mu.Unlock()
+
+	addr := conn.opts.Addr
+	go func() {
+		defer func() {
+			cancel()
+			b.close()
+			a.mu.Lock()
+			delete(a.benchmarks, b.id)
+			a.mu.Unlock()
+		}()
+
+		res := b.run(runCtx, func(p benchProgress) {
+			runtime.EventsEmit(a.ctx, eventBenchProgress, p)
+		})
+		res.Addr = addr
+
+		var val bytes.Buffer
+		if err := gob.NewEncoder(&val).Encode(res); err != nil {
+			runtime.LogError(a.ctx, fmt.Sprintf("failed to encode benchmark result: %v", err))
+		} else if err := a.store.set(benchKey(addr, method, res.ID), val.Bytes()); err != nil {
+			runtime.LogError(a.ctx, fmt.Sprintf("failed to store benchmark result: %v", err))
+		}
+		runtime.EventsEmit(a.ctx, eventBenchEnded, res)
+	}()
+
+	return b.id, nil
+}
+
+// StopBenchmark ends a running benchmark, keeping the results so far
+func (a *api) StopBenchmark(id string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if cancel, ok := a.benchmarks[id]; ok {
+		cancel()
+	}
+}
+
+// ListBenchmarks returns the saved benchmark results of the method on the
+// current workspace, oldest first
+func (a *api) ListBenchmarks(method string) ([]benchResult, error) {
+	opts, err := a.GetWorkspaceOptions()
+	if err != nil {
+		return nil, err
+	}
+	items, err := a.store.list([]byte(benchKeyPrefix + hash(opts.Addr, method) + "_"))
+	if err != nil {
+		return nil, err
+	}
+	var results []benchResult
+	for _, val := range items {
+		var res benchResult
+		if err := gob.NewDecoder(bytes.NewBuffer(val)).Decode(&res); err != nil {
+			return results, err
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+// DeleteBenchmark removes a saved benchmark result of the method on the
+// current workspace
+func (a *api) DeleteBenchmark(method, id string) error {
+	opts, err := a.GetWorkspaceOptions()
+	if err != nil {
+		return err
+	}
+	return a.store.del(benchKey(opts.Addr, method, id))
+}
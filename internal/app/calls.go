@@ -0,0 +1,218 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+type ctxCallKey struct{}
+
+var (
+	errCallNotFound = errors.New("app: no in-flight call found")
+	errStreamClosed = errors.New("app: request stream already closed")
+	errCallEnded    = errors.New("app: call has ended")
+)
+
+// call is the handle for a single in-flight RPC
+type call struct {
+	id        string
+	workspace string
+	method    string
+	desc      protoreflect.MethodDescriptor
+	conn      *connection
+	started   time.Time
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu      sync.Mutex // protects closed
+	closed  bool
+	stop    chan struct{}  // closed by closeSend to abandon pending sends
+	sending sync.WaitGroup // sends in progress, which must finish before reqs is closed
+	reqs    chan proto.Message
+
+	// onRecv, if set before the call is run, is called with every response
+	onRecv func(proto.Message)
+}
+
+type callInfo struct {
+	ID           string `json:"id"`
+	Workspace    string `json:"workspace"`
+	Method       string `json:"method"`
+	ClientStream bool   `json:"client_stream"`
+	ServerStream bool   `json:"server_stream"`
+	Started      string `json:"started"`
+}
+
+func newCall(ctx context.Context, conn *connection, method string, md protoreflect.MethodDescriptor) *call {
+	c := &call{
+		id:        uuid.Must(uuid.NewV4()).String(),
+		workspace: conn.id,
+		method:    method,
+		desc:      md,
+		conn:      conn,
+		started:   time.Now(),
+		done:      make(chan struct{}),
+		stop:      make(chan struct{}),
+		reqs:      make(chan proto.Message, 16),
+	}
+	ctx = context.WithValue(ctx, ctxCallKey{}, c.id)
+	if conn.opts.Timeout > 0 {
+		c.ctx, c.cancel = context.WithTimeout(ctx, seconds(conn.opts.Timeout))
+	} else {
+		c.ctx, c.cancel = context.WithCancel(ctx)
+	}
+	return c
+}
+
+// limit gives a call that hasn't run yet the timeout d, unless its workspace
+// sets a timeout of its own
+func (c *call) limit(d time.Duration) {
+	if c.conn.opts.Timeout > 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(c.ctx, d)
+	parent := c.cancel
+	c.ctx = ctx
+	c.cancel = func() {
+		cancel()
+		parent()
+	}
+}
+
+func (c *call) info() callInfo {
+	return callInfo{
+		ID:           c.id,
+		Workspace:    c.workspace,
+		Method:       c.method,
+		ClientStream: c.desc.IsStreamingClient(),
+		ServerStream: c.desc.IsStreamingServer(),
+		Started:      c.started.Format(time.RFC3339Nano),
+	}
+}
+
+// send queues a request message on a client or bidirectional stream. The
+// lock isn't held while waiting for room in the queue, so the stream can
+// still be closed or cancelled.
+func (c *call) send(m proto.Message) error {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return errStreamClosed
+	}
+	c.sending.Add(1)
+	c.mu.Unlock()
+	defer c.sending.Done()
+
+	select {
+	case c.reqs <- m:
+		return nil
+	case <-c.stop:
+		return errStreamClosed
+	case <-c.done:
+		return errCallEnded
+	}
+}
+
+// closeSend stops the call sending any further request messages
+func (c *call) closeSend() {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return
+	}
+	c.closed = true
+	close(c.stop)
+	c.mu.Unlock()
+
+	c.sending.Wait()
+	close(c.reqs)
+}
+
+func (c *call) received(m proto.Message) {
+	if c.onRecv != nil {
+		c.onRecv(m)
+	}
+}
+
+func (c *call) inFlight() bool {
+	select {
+	case <-c.done:
+		return false
+	default:
+		return true
+	}
+}
+
+// callIDFromContext returns the ID of the call a stats event belongs to
+func callIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(ctxCallKey{}).(string)
+	return id
+}
+
+func (a *api) addCall(c *call) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.calls[c.id] = c
+	a.lastCallID = c.id
+}
+
+func (a *api) removeCall(c *call) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.calls, c.id)
+}
+
+// getCall returns the in-flight call by ID, or the most recent one if the
+// ID is empty
+func (a *api) getCall(id string) *call {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if id == "" {
+		id = a.lastCallID
+	}
+	return a.calls[id]
+}
+
+// activeClientStream returns the most recent in-flight client or
+// bidirectional stream of the method in the workspace
+func (a *api) activeClientStream(workspace, method string) *call {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	var found *call
+	for _, c := range a.calls {
+		if c.workspace != workspace || c.method != method || !c.desc.IsStreamingClient() {
+			continue
+		}
+		if found == nil || c.started.After(found.started) {
+			found = c
+		}
+	}
+	return found
+}
+
+// cancelCalls cancels the in-flight calls of the workspace
+func (a *api) cancelCalls(workspace string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, c := range a.calls {
+		if c.workspace == workspace {
+			c.cancel()
+		}
+	}
+}
+
+func (a *api) cancelAllCalls() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, c := range a.calls {
+		c.cancel()
+	}
+}
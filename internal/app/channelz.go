@@ -0,0 +1,438 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	channelzpb "google.golang.org/grpc/channelz/grpc_channelz_v1"
+	"google.golang.org/grpc/channelz/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	channelzTimeout  = 10 * time.Second
+	channelzPageSize = 100
+)
+
+// channelzEvent is a channel trace event
+type channelzEvent struct {
+	Description string     `json:"description"`
+	Severity    string     `json:"severity"`
+	Time        *time.Time `json:"time"`
+}
+
+// channelzChannel is a channel or subchannel, with its subchannels and the
+// sockets it has open
+type channelzChannel struct {
+	ID              int64             `json:"id"`
+	Name            string            `json:"name"`
+	Target          string            `json:"target"`
+	State           string            `json:"state"`
+	CallsStarted    int64             `json:"calls_started"`
+	CallsSucceeded  int64             `json:"calls_succeeded"`
+	CallsFailed     int64             `json:"calls_failed"`
+	LastCallStarted *time.Time        `json:"last_call_started"`
+	Events          []channelzEvent   `json:"events"`
+	Channels        []channelzChannel `json:"channels"`
+	Subchannels     []channelzChannel `json:"subchannels"`
+	Sockets         []channelzSocket  `json:"sockets"`
+}
+
+type channelzServer struct {
+	ID              int64            `json:"id"`
+	Name            string           `json:"name"`
+	CallsStarted    int64            `json:"calls_started"`
+	CallsSucceeded  int64            `json:"calls_succeeded"`
+	CallsFailed     int64            `json:"calls_failed"`
+	LastCallStarted *time.Time       `json:"last_call_started"`
+	ListenSockets   []channelzSocket `json:"listen_sockets"`
+	Sockets         []channelzSocket `json:"sockets"`
+}
+
+type channelzSocket struct {
+	ID                      int64      `json:"id"`
+	Name                    string     `json:"name"`
+	Local                   string     `json:"local"`
+	Remote                  string     `json:"remote"`
+	Security                string     `json:"security"`
+	StreamsStarted          int64      `json:"streams_started"`
+	StreamsSucceeded        int64      `json:"streams_succeeded"`
+	StreamsFailed           int64      `json:"streams_failed"`
+	MessagesSent            int64      `json:"messages_sent"`
+	MessagesReceived        int64      `json:"messages_received"`
+	KeepAlivesSent          int64      `json:"keep_alives_sent"`
+	LastLocalStreamCreated  *time.Time `json:"last_local_stream_created"`
+	LastRemoteStreamCreated *time.Time `json:"last_remote_stream_created"`
+	LastMessageSent         *time.Time `json:"last_message_sent"`
+	LastMessageReceived     *time.Time `json:"last_message_received"`
+}
+
+type channelzReport struct {
+	Channels []channelzChannel `json:"channels"`
+	Servers  []channelzServer  `json:"servers"`
+}
+
+// dialMu is held while clients are created, so the newest channel with a
+// target is known to be the one just created
+var dialMu sync.Mutex
+
+// newestChannelID returns the channelz ID of the newest top channel with the
+// target, or 0 if there is none. Channel IDs only increase.
+func newestChannelID(target string) int64 {
+	l := newLocalChannelz()
+	var id, start int64
+	for {
+		resp, err := l.GetTopChannels(context.Background(), &channelzpb.GetTopChannelsRequest{StartChannelId: start, MaxResults: channelzPageSize})
+		if err != nil {
+			return id
+		}
+		for _, ch := range resp.GetChannel() {
+			start = ch.GetRef().GetChannelId() + 1
+			if ch.GetData().GetTarget() == target {
+				id = ch.GetRef().GetChannelId()
+			}
+		}
+		if resp.GetEnd() || len(resp.GetChannel()) == 0 {
+			return id
+		}
+	}
+}
+
+// localChannelz queries the channelz data of this process through the
+// channelz service implementation, without serving it
+type localChannelz struct {
+	srv channelzpb.ChannelzServer
+}
+
+// RegisterService captures the channelz service implementation
+func (l *localChannelz) RegisterService(_ *grpc.ServiceDesc, impl interface{}) {
+	l.srv = impl.(channelzpb.ChannelzServer)
+}
+
+func newLocalChannelz() *localChannelz {
+	l := &localChannelz{}
+	service.RegisterChannelzServiceToServer(l)
+	return l
+}
+
+func (l *localChannelz) GetTopChannels(ctx context.Context, in *channelzpb.GetTopChannelsRequest, _ ...grpc.CallOption) (*channelzpb.GetTopChannelsResponse, error) {
+	return l.srv.GetTopChannels(ctx, in)
+}
+
+func (l *localChannelz) GetServers(ctx context.Context, in *channelzpb.GetServersRequest, _ ...grpc.CallOption) (*channelzpb.GetServersResponse, error) {
+	return l.srv.GetServers(ctx, in)
+}
+
+func (l *localChannelz) GetServer(ctx context.Context, in *channelzpb.GetServerRequest, _ ...grpc.CallOption) (*channelzpb.GetServerResponse, error) {
+	return l.srv.GetServer(ctx, in)
+}
+
+func (l *localChannelz) GetServerSockets(ctx context.Context, in *channelzpb.GetServerSocketsRequest, _ ...grpc.CallOption) (*channelzpb.GetServerSocketsResponse, error) {
+	return l.srv.GetServerSockets(ctx, in)
+}
+
+func (l *localChannelz) GetChannel(ctx context.Context, in *channelzpb.GetChannelRequest, _ ...grpc.CallOption) (*channelzpb.GetChannelResponse, error) {
+	return l.srv.GetChannel(ctx, in)
+}
+
+func (l *localChannelz) GetSubchannel(ctx context.Context, in *channelzpb.GetSubchannelRequest, _ ...grpc.CallOption) (*channelzpb.GetSubchannelResponse, error) {
+	return l.srv.GetSubchannel(ctx, in)
+}
+
+func (l *localChannelz) GetSocket(ctx context.Context, in *channelzpb.GetSocketRequest, _ ...grpc.CallOption) (*channelzpb.GetSocketResponse, error) {
+	return l.srv.GetSocket(ctx, in)
+}
+
+// channelzCollector resolves the references between channelz entities into
+// a tree
+type channelzCollector struct {
+	ctx context.Context
+	cz  channelzpb.ChannelzClient
+}
+
+func czTime(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+func czAddress(a *channelzpb.Address) string {
+	switch {
+	case a.GetTcpipAddress() != nil:
+		tcp := a.GetTcpipAddress()
+		return net.JoinHostPort(net.IP(tcp.GetIpAddress()).String(), strconv.Itoa(int(tcp.GetPort())))
+	case a.GetUdsAddress() != nil:
+		return "unix:" + a.GetUdsAddress().GetFilename()
+	case a.GetOtherAddress() != nil:
+		return a.GetOtherAddress().GetName()
+	}
+	return ""
+}
+
+func czSecurity(s *channelzpb.Security) string {
+	switch {
+	case s.GetTls() != nil:
+		tls := s.GetTls()
+		name := tls.GetStandardName()
+		if name == "" {
+			name = tls.GetOtherName()
+		}
+		return "tls " + name
+	case s.GetOther() != nil:
+		return s.GetOther().GetName()
+	}
+	return ""
+}
+
+func (c *channelzCollector) socket(id int64) (channelzSocket, error) {
+	resp, err := c.cz.GetSocket(c.ctx, &channelzpb.GetSocketRequest{SocketId: id})
+	if err != nil {
+		return channelzSocket{}, err
+	}
+	s := resp.GetSocket()
+	d := s.GetData()
+	return channelzSocket{
+		ID:                      s.GetRef().GetSocketId(),
+		Name:                    s.GetRef().GetName(),
+		Local:                   czAddress(s.GetLocal()),
+		Remote:                  czAddress(s.GetRemote()),
+		Security:                czSecurity(s.GetSecurity()),
+		StreamsStarted:          d.GetStreamsStarted(),
+		StreamsSucceeded:        d.GetStreamsSucceeded(),
+		StreamsFailed:           d.GetStreamsFailed(),
+		MessagesSent:            d.GetMessagesSent(),
+		MessagesReceived:        d.GetMessagesReceived(),
+		KeepAlivesSent:          d.GetKeepAlivesSent(),
+		LastLocalStreamCreated:  czTime(d.GetLastLocalStreamCreatedTimestamp()),
+		LastRemoteStreamCreated: czTime(d.GetLastRemoteStreamCreatedTimestamp()),
+		LastMessageSent:         czTime(d.GetLastMessageSentTimestamp()),
+		LastMessageReceived:     czTime(d.GetLastMessageReceivedTimestamp()),
+	}, nil
+}
+
+func (c *channelzCollector) sockets(refs []*channelzpb.SocketRef) ([]channelzSocket, error) {
+	var socks []channelzSocket
+	for _, ref := range refs {
+		s, err := c.socket(ref.GetSocketId())
+		if status.Code(err) == codes.NotFound {
+			// Closed since it was listed
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		socks = append(socks, s)
+	}
+	return socks, nil
+}
+
+// channel builds the channel or subchannel from its data and references
+func (c *channelzCollector) channel(id int64, name string, d *channelzpb.ChannelData, chs []*channelzpb.ChannelRef, subs []*channelzpb.SubchannelRef, socks []*channelzpb.SocketRef) (channelzChannel, error) {
+	ch := channelzChannel{
+		ID:              id,
+		Name:            name,
+		Target:          d.GetTarget(),
+		State:           d.GetState().GetState().String(),
+		CallsStarted:    d.GetCallsStarted(),
+		CallsSucceeded:  d.GetCallsSucceeded(),
+		CallsFailed:     d.GetCallsFailed(),
+		LastCallStarted: czTime(d.GetLastCallStartedTimestamp()),
+	}
+	for _, ev := range d.GetTrace().GetEvents() {
+		ch.Events = append(ch.Events, channelzEvent{
+			Description: ev.GetDescription(),
+			Severity:    ev.GetSeverity().String(),
+			Time:        czTime(ev.GetTimestamp()),
+		})
+	}
+
+	for _, ref := range chs {
+		resp, err := c.cz.GetChannel(c.ctx, &channelzpb.GetChannelRequest{ChannelId: ref.GetChannelId()})
+		if status.Code(err) == codes.NotFound {
+			continue
+		}
+		if err != nil {
+			return ch, err
+		}
+		nested, err := c.topChannel(resp.GetChannel())
+		if err != nil {
+			return ch, err
+		}
+		ch.Channels = append(ch.Channels, nested)
+	}
+	for _, ref := range subs {
+		resp, err := c.cz.GetSubchannel(c.ctx, &channelzpb.GetSubchannelRequest{SubchannelId: ref.GetSubchannelId()})
+		if status.Code(err) == codes.NotFound {
+			continue
+		}
+		if err != nil {
+			return ch, err
+		}
+		sc := resp.GetSubchannel()
+		sub, err := c.channel(sc.GetRef().GetSubchannelId(), sc.GetRef().GetName(), sc.GetData(), sc.GetChannelRef(), sc.GetSubchannelRef(), sc.GetSocketRef())
+		if err != nil {
+			return ch, err
+		}
+		ch.Subchannels = append(ch.Subchannels, sub)
+	}
+
+	var err error
+	ch.Sockets, err = c.sockets(socks)
+	return ch, err
+}
+
+func (c *channelzCollector) topChannel(ch *channelzpb.Channel) (channelzChannel, error) {
+	return c.channel(ch.GetRef().GetChannelId(), ch.GetRef().GetName(), ch.GetData(), ch.GetChannelRef(), ch.GetSubchannelRef(), ch.GetSocketRef())
+}
+
+// channels returns the top channels that keep reports true for
+func (c *channelzCollector) channels(keep func(*channelzpb.Channel) bool) ([]channelzChannel, error) {
+	var chs []channelzChannel
+	var start int64
+	for {
+		resp, err := c.cz.GetTopChannels(c.ctx, &channelzpb.GetTopChannelsRequest{StartChannelId: start, MaxResults: channelzPageSize})
+		if err != nil {
+			return nil, err
+		}
+		for _, pb := range resp.GetChannel() {
+			start = pb.GetRef().GetChannelId() + 1
+			if keep != nil && !keep(pb) {
+				continue
+			}
+			ch, err := c.topChannel(pb)
+			if err != nil {
+				return nil, err
+			}
+			chs = append(chs, ch)
+		}
+		if resp.GetEnd() || len(resp.GetChannel()) == 0 {
+			return chs, nil
+		}
+	}
+}
+
+func (c *channelzCollector) serverSockets(id int64) ([]channelzSocket, error) {
+	var socks []channelzSocket
+	var start int64
+	for {
+		resp, err := c.cz.GetServerSockets(c.ctx, &channelzpb.GetServerSocketsRequest{ServerId: id, StartSocketId: start, MaxResults: channelzPageSize})
+		if err != nil {
+			return nil, err
+		}
+		refs := resp.GetSocketRef()
+		for _, ref := range refs {
+			start = ref.GetSocketId() + 1
+		}
+		page, err := c.sockets(refs)
+		if err != nil {
+			return nil, err
+		}
+		socks = append(socks, page...)
+		if resp.GetEnd() || len(refs) == 0 {
+			return socks, nil
+		}
+	}
+}
+
+func (c *channelzCollector) servers() ([]channelzServer, error) {
+	var srvs []channelzServer
+	var start int64
+	for {
+		resp, err := c.cz.GetServers(c.ctx, &channelzpb.GetServersRequest{StartServerId: start, MaxResults: channelzPageSize})
+		if err != nil {
+			return nil, err
+		}
+		for _, pb := range resp.GetServer() {
+			start = pb.GetRef().GetServerId() + 1
+			d := pb.GetData()
+			srv := channelzServer{
+				ID:              pb.GetRef().GetServerId(),
+				Name:            pb.GetRef().GetName(),
+				CallsStarted:    d.GetCallsStarted(),
+				CallsSucceeded:  d.GetCallsSucceeded(),
+				CallsFailed:     d.GetCallsFailed(),
+				LastCallStarted: czTime(d.GetLastCallStartedTimestamp()),
+			}
+			if srv.ListenSockets, err = c.sockets(pb.GetListenSocket()); err != nil {
+				return nil, err
+			}
+			if srv.Sockets, err = c.serverSockets(srv.ID); err != nil {
+				return nil, err
+			}
+			srvs = append(srvs, srv)
+		}
+		if resp.GetEnd() || len(resp.GetServer()) == 0 {
+			return srvs, nil
+		}
+	}
+}
+
+// GetChannelz queries the grpc.channelz.v1.Channelz service of the current
+// workspace for its channels and servers, along with their sockets
+func (a *api) GetChannelz() (*channelzReport, error) {
+	conn := a.current()
+	if conn == nil {
+		return nil, errNoConn
+	}
+	cc := conn.client.grpcConn()
+	if cc == nil {
+		return nil, errors.New("channelz needs a grpc connection")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), channelzTimeout)
+	defer cancel()
+	c := &channelzCollector{
+		ctx: context.WithValue(ctx, ctxInternalKey{}, struct{}{}),
+		cz:  channelzpb.NewChannelzClient(cc),
+	}
+
+	var r channelzReport
+	var err error
+	if r.Channels, err = c.channels(nil); err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			return nil, errors.New("the server does not expose channelz")
+		}
+		return nil, fmt.Errorf("failed to get channels: %v", err)
+	}
+	if r.Servers, err = c.servers(); err != nil {
+		return nil, fmt.Errorf("failed to get servers: %v", err)
+	}
+	return &r, nil
+}
+
+// GetClientChannelz returns the channelz data of Wombat's own connection to
+// the current workspace, for diagnosing connection problems
+func (a *api) GetClientChannelz() (*channelzReport, error) {
+	conn := a.current()
+	if conn == nil {
+		return nil, errNoConn
+	}
+	id := conn.client.channelzChannelID()
+	if id == 0 {
+		return nil, errors.New("channelz needs a grpc connection")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), channelzTimeout)
+	defer cancel()
+	c := &channelzCollector{ctx: ctx, cz: newLocalChannelz()}
+
+	// Benchmarks, health checks and other workspaces may have channels to
+	// the same target
+	chs, err := c.channels(func(ch *channelzpb.Channel) bool {
+		return ch.GetRef().GetChannelId() == id
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &channelzReport{Channels: chs}, nil
+}
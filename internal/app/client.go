@@ -7,104 +7,234 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"sync"
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
 	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/stats"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 var errNoConn = errors.New("app: no connection available")
 
+const defaultConnectTimeout = 10 * time.Second
+
 type client struct {
+	mu   sync.Mutex // protects conn and channelzID, which are set while connecting
 	conn *grpc.ClientConn
+	// channelzID is the channelz ID of conn
+	channelzID int64
+	http       httpTransport
+	timeline   *connTimeline
 }
 
 type transportCreds struct {
 	credentials.TransportCredentials
-	errc chan<- error
+	report func(error)
 }
 
 func (t *transportCreds) ClientHandshake(ctx context.Context, addr string, in net.Conn) (net.Conn, credentials.AuthInfo, error) {
 	out, auth, err := t.TransportCredentials.ClientHandshake(ctx, addr, in)
 	if err != nil {
-		t.errc <- err
+		t.report(err)
 	}
 	return out, auth, err
 }
 
+// defaultMinConnectTimeout is the grpc default, which isn't exported
+const defaultMinConnectTimeout = 20 * time.Second
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
+
+// validateKeepalive rejects settings that grpc would ignore, as keepalive
+// pings are off without a time
+func validateKeepalive(ka keepaliveOptions) error {
+	if ka.Time > 0 {
+		return nil
+	}
+	if ka.Timeout > 0 {
+		return fmt.Errorf("keepalive timeout needs a keepalive time")
+	}
+	if ka.PermitWithoutStream {
+		return fmt.Errorf("keepalive permit without stream needs a keepalive time")
+	}
+	return nil
+}
+
+// connectParams returns the dial options for the keepalive and backoff
+// settings of o, only overriding the grpc defaults that have been set.
+func connectParams(o options) []grpc.DialOption {
+	var opts []grpc.DialOption
+
+	// Keepalive pings are off without a time, which grpc would otherwise
+	// raise to its 10s minimum
+	if ka := o.Keepalive; ka.Time > 0 {
+		opts = append(opts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                seconds(ka.Time),
+			Timeout:             seconds(ka.Timeout),
+			PermitWithoutStream: ka.PermitWithoutStream,
+		}))
+	}
+
+	bo := o.Backoff
+	if bo != (backoffOptions{}) {
+		cp := grpc.ConnectParams{
+			Backoff:           backoff.DefaultConfig,
+			MinConnectTimeout: defaultMinConnectTimeout,
+		}
+		if bo.MinConnectTimeout > 0 {
+			cp.MinConnectTimeout = seconds(bo.MinConnectTimeout)
+		}
+		if bo.BaseDelay > 0 {
+			cp.Backoff.BaseDelay = seconds(bo.BaseDelay)
+		}
+		if bo.Multiplier > 0 {
+			cp.Backoff.Multiplier = bo.Multiplier
+		}
+		if bo.Jitter > 0 {
+			cp.Backoff.Jitter = bo.Jitter
+		}
+		if bo.MaxDelay > 0 {
+			cp.Backoff.MaxDelay = seconds(bo.MaxDelay)
+		}
+		opts = append(opts, grpc.WithConnectParams(cp))
+	}
+
+	return opts
+}
+
+func tlsConfig(o options) (*tls.Config, error) {
+	var tlsCfg tls.Config
+	tlsCfg.InsecureSkipVerify = o.Insecure
+	tlsCfg.ServerName = o.ServerName
+
+	if o.Clientcert != "" {
+		cert, err := tls.X509KeyPair([]byte(o.Clientcert), []byte(o.Clientkey))
+		if err != nil {
+			return nil, err
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+
+	var err error
+	tlsCfg.RootCAs, err = x509.SystemCertPool()
+	if err != nil {
+		tlsCfg.RootCAs = x509.NewCertPool()
+	}
+
+	if o.Rootca != "" {
+		tlsCfg.RootCAs.AppendCertsFromPEM([]byte(o.Rootca))
+	}
+
+	return &tlsCfg, nil
+}
+
 func (c *client) connect(o options, h stats.Handler) error {
+	if isHTTPTransport(o.Transport) {
+		var err error
+		c.http, err = newHTTPTransport(o, h)
+		return err
+	}
+
 	errc := make(chan error, 1)
+	done := func(err error) {
+		select {
+		case errc <- err:
+		default:
+		}
+	}
+	// report is used by the transport credentials for handshake failures,
+	// which can happen long after the initial connection is established.
+	report := func(err error) {
+		c.timeline.add(connEvent{
+			Kind:   connEventHandshake,
+			Reason: err.Error(),
+		})
+		done(err)
+	}
+
 	go func() {
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		timeout := defaultConnectTimeout
+		if o.ConnectTimeout > 0 {
+			timeout = seconds(o.ConnectTimeout)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
 		defer cancel()
 
 		opts := []grpc.DialOption{
-			grpc.WithStatsHandler(h),
 			grpc.WithUserAgent(fmt.Sprintf("%s/%s", appName, semver)),
 		}
+		if h != nil {
+			opts = append(opts, grpc.WithStatsHandler(h))
+		}
+		opts = append(opts, connectParams(o)...)
 
-		if !o.Plaintext {
-			var tlsCfg tls.Config
-			tlsCfg.InsecureSkipVerify = o.Insecure
-
-			if o.Clientcert != "" {
-				cert, err := tls.X509KeyPair([]byte(o.Clientcert), []byte(o.Clientkey))
-				if err != nil {
-					errc <- err
-					return
-				}
-				tlsCfg.Certificates = []tls.Certificate{cert}
-			}
+		if o.Authority != "" {
+			opts = append(opts, grpc.WithAuthority(o.Authority))
+		}
+		if o.ServiceConfig != "" {
+			opts = append(opts, grpc.WithDefaultServiceConfig(o.ServiceConfig))
+		}
 
-			var err error
-			tlsCfg.RootCAs, err = x509.SystemCertPool()
+		if !o.Plaintext {
+			tlsCfg, err := tlsConfig(o)
 			if err != nil {
-				tlsCfg.RootCAs = x509.NewCertPool()
-			}
-
-			if o.Rootca != "" {
-				tlsCfg.RootCAs.AppendCertsFromPEM([]byte(o.Rootca))
+				done(err)
+				return
 			}
 
 			creds := &transportCreds{
-				credentials.NewTLS(&tlsCfg),
-				errc,
+				credentials.NewTLS(tlsCfg),
+				report,
 			}
 			opts = append(opts, grpc.WithTransportCredentials(creds))
 		} else {
 			opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
 		}
 
-		var err error
-		c.conn, err = grpc.NewClient(o.Addr, opts...)
+		dialMu.Lock()
+		conn, err := grpc.NewClient(o.Addr, opts...)
+		var channelzID int64
+		if err == nil {
+			channelzID = newestChannelID(conn.Target())
+		}
+		dialMu.Unlock()
 		if err != nil {
-			errc <- err
+			done(err)
 			return
 		}
+		c.mu.Lock()
+		c.conn = conn
+		c.channelzID = channelzID
+		c.mu.Unlock()
 
-		c.conn.Connect()
+		conn.Connect()
 
 		// Wait for connection to be READY
-		state := c.conn.GetState()
+		state := conn.GetState()
 		fmt.Printf("Initial connection state: %s\n", state.String())
 		for state != connectivity.Ready {
-			if !c.conn.WaitForStateChange(ctx, state) {
+			if !conn.WaitForStateChange(ctx, state) {
 				fmt.Printf("Context timed out while in state: %s\n", state.String())
-				errc <- ctx.Err()
+				done(ctx.Err())
 				return
 			}
-			state = c.conn.GetState()
+			state = conn.GetState()
 			fmt.Printf("Connection state changed to: %s\n", state.String())
 			if state == connectivity.TransientFailure || state == connectivity.Shutdown {
-				errc <- fmt.Errorf("connection in state: %s", state.String())
+				done(fmt.Errorf("connection in state: %s", state.String()))
 				return
 			}
 		}
-		close(errc)
+		done(nil)
 	}()
 
 	if err := <-errc; err != nil {
@@ -113,18 +243,60 @@ func (c *client) connect(o options, h stats.Handler) error {
 	return nil
 }
 
+// grpcConn returns the client connection, or nil if it hasn't been created
+func (c *client) grpcConn() *grpc.ClientConn {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.conn
+}
+
+// channelzChannelID returns the channelz ID of the grpc connection, or 0 if
+// there is none
+func (c *client) channelzChannelID() int64 {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.channelzID
+}
+
 func (c *client) invoke(ctx context.Context, method string, req, resp proto.Message) error {
-	if c.conn == nil {
+	if c.http != nil {
+		return c.http.invoke(ctx, method, req, resp)
+	}
+	conn := c.grpcConn()
+	if conn == nil {
 		return errNoConn
 	}
 
-	return c.conn.Invoke(ctx, method, req, resp)
+	return conn.Invoke(ctx, method, req, resp)
 }
 
-func (c *client) invokeServerStream(ctx context.Context, method string, req proto.Message) (grpc.ClientStream, error) {
-	if c.conn == nil {
+func (c *client) newStream(ctx context.Context, sd *grpc.StreamDesc, method string) (grpc.ClientStream, error) {
+	if c.http != nil {
+		return c.http.newStream(ctx, sd, method)
+	}
+	conn := c.grpcConn()
+	if conn == nil {
 		return nil, errNoConn
 	}
+	return conn.NewStream(ctx, sd, method)
+}
+
+// streamDesc returns the stream description of the method
+func streamDesc(method string, md protoreflect.MethodDescriptor) *grpc.StreamDesc {
+	return &grpc.StreamDesc{
+		StreamName:    method,
+		ClientStreams: md.IsStreamingClient(),
+		ServerStreams: md.IsStreamingServer(),
+	}
+}
+
+func (c *client) invokeServerStream(ctx context.Context, method string, req proto.Message) (grpc.ClientStream, error) {
 	sd := &grpc.StreamDesc{
 		StreamName:    method,
 		ClientStreams: false,
@@ -132,7 +304,7 @@ func (c *client) invokeServerStream(ctx context.Context, method string, req prot
 	}
 	ctx, cancel := context.WithCancel(ctx)
 	_ = cancel // avoid go vet error
-	s, err := c.conn.NewStream(ctx, sd, method)
+	s, err := c.newStream(ctx, sd, method)
 	if err != nil {
 		return nil, err
 	}
@@ -148,32 +320,27 @@ func (c *client) invokeServerStream(ctx context.Context, method string, req prot
 }
 
 func (c *client) invokeClientStream(ctx context.Context, method string) (grpc.ClientStream, error) {
-	if c.conn == nil {
-		return nil, errNoConn
-	}
 	sd := &grpc.StreamDesc{
 		StreamName:    method,
 		ClientStreams: true,
 		ServerStreams: false,
 	}
-	return c.conn.NewStream(ctx, sd, method)
+	return c.newStream(ctx, sd, method)
 }
 
 func (c *client) invokeBidiStream(ctx context.Context, method string) (grpc.ClientStream, error) {
-	if c.conn == nil {
-		return nil, errNoConn
-	}
 	sd := &grpc.StreamDesc{
 		StreamName:    method,
 		ClientStreams: true,
 		ServerStreams: true,
 	}
-	return c.conn.NewStream(ctx, sd, method)
+	return c.newStream(ctx, sd, method)
 }
 
 func (c *client) close() error {
-	if c == nil || c.conn == nil {
+	conn := c.grpcConn()
+	if conn == nil {
 		return nil
 	}
-	return c.conn.Close()
+	return conn.Close()
 }
@@ -0,0 +1,416 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	statuspb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const (
+	connectProtocolVersion = "1"
+	connectTrailerPrefix   = "Trailer-"
+
+	connectFlagCompressed = 0x01
+	connectFlagEndStream  = 0x02
+)
+
+var connectCodes = map[codes.Code]string{
+	codes.Canceled:           "canceled",
+	codes.Unknown:            "unknown",
+	codes.InvalidArgument:    "invalid_argument",
+	codes.DeadlineExceeded:   "deadline_exceeded",
+	codes.NotFound:           "not_found",
+	codes.AlreadyExists:      "already_exists",
+	codes.PermissionDenied:   "permission_denied",
+	codes.ResourceExhausted:  "resource_exhausted",
+	codes.FailedPrecondition: "failed_precondition",
+	codes.Aborted:            "aborted",
+	codes.OutOfRange:         "out_of_range",
+	codes.Unimplemented:      "unimplemented",
+	codes.Internal:           "internal",
+	codes.Unavailable:        "unavailable",
+	codes.DataLoss:           "data_loss",
+	codes.Unauthenticated:    "unauthenticated",
+}
+
+func connectCodeFromString(s string) codes.Code {
+	for c, name := range connectCodes {
+		if name == s {
+			return c
+		}
+	}
+	return codes.Unknown
+}
+
+// connectClient sends calls using the Connect protocol. Unary calls are a
+// plain HTTP POST of the encoded message, streaming calls use the enveloped
+// format. Requests are sent in full before the response is read, so
+// bidirectional streaming is not supported.
+type connectClient struct {
+	http    *http.Client
+	baseURL string
+	codec   string
+	handler stats.Handler
+}
+
+func newConnectClient(o options, h stats.Handler) (*connectClient, error) {
+	base, err := httpBaseURL(o)
+	if err != nil {
+		return nil, err
+	}
+	hc, err := newHTTPClient(o)
+	if err != nil {
+		return nil, err
+	}
+	codec := o.Codec
+	if codec == "" {
+		codec = codecProto
+	}
+	return &connectClient{
+		http:    hc,
+		baseURL: base,
+		codec:   codec,
+		handler: h,
+	}, nil
+}
+
+func (c *connectClient) marshal(m proto.Message) ([]byte, error) {
+	if c.codec == codecJSON {
+		return protojson.Marshal(m)
+	}
+	return proto.Marshal(m)
+}
+
+func (c *connectClient) unmarshal(data []byte, m proto.Message) error {
+	if c.codec == codecJSON {
+		return (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, m)
+	}
+	return proto.Unmarshal(data, m)
+}
+
+func (c *connectClient) newRequest(ctx context.Context, method, contentType string, body []byte) (*http.Request, metadata.MD, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+method, bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, err
+	}
+	md := setRequestMetadata(ctx, req.Header)
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Connect-Protocol-Version", connectProtocolVersion)
+	req.Header.Set("User-Agent", fmt.Sprintf("%s/%s connect", appName, semver))
+	if dl, ok := ctx.Deadline(); ok {
+		ms := time.Until(dl).Milliseconds()
+		if ms < 1 {
+			ms = 1
+		}
+		req.Header.Set("Connect-Timeout-Ms", strconv.FormatInt(ms, 10))
+	}
+	return req, md, nil
+}
+
+func (c *connectClient) invoke(ctx context.Context, method string, req, resp proto.Message) (rerr error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	ctx, st := newHTTPStats(ctx, c.handler, method, false, false)
+
+	var trailer metadata.MD
+	defer func() {
+		if trailer != nil {
+			st.inTrailer(trailer)
+		}
+		st.end(trailer, rerr)
+	}()
+
+	data, err := c.marshal(req)
+	if err != nil {
+		return status.Errorf(codes.Internal, "connect: failed to marshal request: %v", err)
+	}
+	hreq, md, err := c.newRequest(ctx, method, "application/"+c.codec, data)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	st.outHeader(md)
+	st.outPayload(req, data, len(data))
+
+	hresp, err := c.http.Do(hreq)
+	if err != nil {
+		return connectTransportError(ctx, err)
+	}
+	defer hresp.Body.Close()
+
+	header, trailer := splitConnectHeader(hresp.Header)
+	st.inHeader(header, 0)
+
+	body, err := io.ReadAll(hresp.Body)
+	if err != nil {
+		return connectTransportError(ctx, err)
+	}
+	if hresp.StatusCode != http.StatusOK {
+		return connectErrorFromBody(hresp.StatusCode, body)
+	}
+	if err := c.unmarshal(body, resp); err != nil {
+		return status.Errorf(codes.Internal, "connect: failed to unmarshal response: %v", err)
+	}
+	st.inPayload(resp, body, len(body))
+	return nil
+}
+
+func (c *connectClient) newStream(ctx context.Context, desc *grpc.StreamDesc, method string) (grpc.ClientStream, error) {
+	if desc.ClientStreams && desc.ServerStreams {
+		return nil, status.Error(codes.Unimplemented, "connect over HTTP/1.1 does not support bidirectional streaming")
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	ctx, st := newHTTPStats(ctx, c.handler, method, desc.ClientStreams, desc.ServerStreams)
+	return &connectStream{
+		client: c,
+		ctx:    ctx,
+		cancel: cancel,
+		method: method,
+		desc:   desc,
+		stats:  st,
+	}, nil
+}
+
+// connectStream implements grpc.ClientStream for the Connect streaming
+// protocol. Request messages are buffered until CloseSend.
+type connectStream struct {
+	client *connectClient
+	ctx    context.Context
+	cancel context.CancelFunc
+	method string
+	desc   *grpc.StreamDesc
+	stats  *httpStats
+
+	reqBody bytes.Buffer
+	sent    bool
+
+	resp    *http.Response
+	header  metadata.MD
+	trailer metadata.MD
+	done    bool
+	err     error
+}
+
+func (s *connectStream) Header() (metadata.MD, error) {
+	if s.resp == nil {
+		return nil, errors.New("connect: request not sent")
+	}
+	return s.header, nil
+}
+
+func (s *connectStream) Trailer() metadata.MD {
+	return s.trailer
+}
+
+func (s *connectStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *connectStream) SendMsg(m any) error {
+	if s.sent {
+		return errors.New("connect: request already sent")
+	}
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return fmt.Errorf("connect: message is not a proto message: %T", m)
+	}
+	data, err := s.client.marshal(msg)
+	if err != nil {
+		return fmt.Errorf("connect: failed to marshal request: %v", err)
+	}
+	writeEnvelope(&s.reqBody, 0, data)
+	s.stats.outPayload(msg, data, len(data)+envelopeHeaderLen)
+	return nil
+}
+
+// CloseSend sends the buffered request messages as the HTTP request body
+func (s *connectStream) CloseSend() error {
+	if s.sent {
+		return nil
+	}
+	s.sent = true
+
+	contentType := "application/connect+" + s.client.codec
+	req, md, err := s.client.newRequest(s.ctx, s.method, contentType, s.reqBody.Bytes())
+	if err != nil {
+		s.finish(nil, status.Error(codes.Internal, err.Error()))
+		return nil
+	}
+	s.stats.outHeader(md)
+
+	resp, err := s.client.http.Do(req)
+	if err != nil {
+		s.finish(nil, connectTransportError(s.ctx, err))
+		return nil
+	}
+	s.resp = resp
+	s.header = metadataFromHeader(resp.Header)
+	s.stats.inHeader(s.header, 0)
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+		s.finish(nil, connectErrorFromBody(resp.StatusCode, body))
+	}
+	// Errors are returned by RecvMsg, as they would be for a grpc stream
+	return nil
+}
+
+func (s *connectStream) RecvMsg(m any) error {
+	if s.done {
+		return s.result()
+	}
+	if !s.sent {
+		// Server streams send their single request without an explicit
+		// CloseSend from the caller
+		if err := s.CloseSend(); err != nil {
+			return err
+		}
+		if s.done {
+			return s.result()
+		}
+	}
+
+	flags, data, err := readEnvelope(s.resp.Body)
+	if err == io.EOF {
+		return s.finish(nil, status.Error(codes.Internal, "connect: stream ended without end-stream message"))
+	}
+	if err != nil {
+		return s.finish(nil, connectTransportError(s.ctx, err))
+	}
+	if flags&connectFlagCompressed != 0 {
+		return s.finish(nil, status.Error(codes.Internal, "connect: compressed messages are not supported"))
+	}
+	if flags&connectFlagEndStream != 0 {
+		trailer, err := parseConnectEndStream(data)
+		return s.finish(trailer, err)
+	}
+
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return fmt.Errorf("connect: message is not a proto message: %T", m)
+	}
+	if err := s.client.unmarshal(data, msg); err != nil {
+		return s.finish(nil, status.Errorf(codes.Internal, "connect: failed to unmarshal response: %v", err))
+	}
+	s.stats.inPayload(msg, data, len(data)+envelopeHeaderLen)
+	return nil
+}
+
+func (s *connectStream) finish(trailer metadata.MD, err error) error {
+	if s.done {
+		return s.result()
+	}
+	s.done = true
+	s.err = err
+	s.trailer = trailer
+	if trailer != nil {
+		s.stats.inTrailer(trailer)
+	}
+	s.stats.end(trailer, err)
+	if s.resp != nil {
+		s.resp.Body.Close()
+	}
+	s.cancel()
+	return s.result()
+}
+
+func (s *connectStream) result() error {
+	if s.err != nil {
+		return s.err
+	}
+	return io.EOF
+}
+
+type connectErrorDetail struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type connectError struct {
+	Code    string               `json:"code"`
+	Message string               `json:"message"`
+	Details []connectErrorDetail `json:"details"`
+}
+
+func (e *connectError) status() *status.Status {
+	st := &statuspb.Status{
+		Code:    int32(connectCodeFromString(e.Code)),
+		Message: e.Message,
+	}
+	for _, d := range e.Details {
+		val, err := base64.RawStdEncoding.DecodeString(strings.TrimRight(d.Value, "="))
+		if err != nil {
+			continue
+		}
+		st.Details = append(st.Details, &anypb.Any{
+			TypeUrl: "type.googleapis.com/" + d.Type,
+			Value:   val,
+		})
+	}
+	return status.FromProto(st)
+}
+
+// connectErrorFromBody maps a non-200 unary or stream response to a status
+func connectErrorFromBody(code int, body []byte) error {
+	var e connectError
+	if err := json.Unmarshal(body, &e); err != nil || e.Code == "" {
+		return statusFromHTTP(code, strings.TrimSpace(string(body))).Err()
+	}
+	return e.status().Err()
+}
+
+// parseConnectEndStream parses the final message of a stream, returning its
+// metadata as the trailer and any error it carries
+func parseConnectEndStream(data []byte) (metadata.MD, error) {
+	var end struct {
+		Error    *connectError       `json:"error"`
+		Metadata map[string][]string `json:"metadata"`
+	}
+	if err := json.Unmarshal(data, &end); err != nil {
+		return nil, status.Errorf(codes.Internal, "connect: invalid end-stream message: %v", err)
+	}
+	trailer := metadataFromHeader(http.Header(end.Metadata))
+	if end.Error != nil {
+		return trailer, end.Error.status().Err()
+	}
+	return trailer, nil
+}
+
+// splitConnectHeader separates the trailers of a unary response, which are
+// sent as headers with a "Trailer-" prefix
+func splitConnectHeader(h http.Header) (header, trailer metadata.MD) {
+	hdr := http.Header{}
+	trl := http.Header{}
+	for k, vs := range h {
+		if strings.HasPrefix(k, connectTrailerPrefix) {
+			trl[strings.TrimPrefix(k, connectTrailerPrefix)] = vs
+			continue
+		}
+		hdr[k] = vs
+	}
+	return metadataFromHeader(hdr), metadataFromHeader(trl)
+}
+
+func connectTransportError(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return status.FromContextError(ctx.Err()).Err()
+	}
+	return status.Error(codes.Unavailable, err.Error())
+}
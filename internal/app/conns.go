@@ -0,0 +1,237 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// connection is the client for a single workspace along with the schema
+// that was loaded for it
+type connection struct {
+	id               string
+	opts             options
+	client           *client
+	cancelMonitoring context.CancelFunc
+
+	// loaded is closed once the proto files are first loaded or fail to, or
+	// the connection is closed
+	loaded     chan struct{}
+	loadedOnce sync.Once
+
+	mu         sync.Mutex // protects closed, protofiles, symbols, validator and imported
+	closed     bool
+	protofiles *protoregistry.Files
+	// symbols is the search index of protofiles, built on the first search
+	symbols *symbolIndex
+	// validator checks requests against the rules of protofiles
+	validator *validator
+	// imported is the call to select once the proto files are loaded
+	imported *importedCommand
+}
+
+type connectionInfo struct {
+	ID    string `json:"id"`
+	Addr  string `json:"addr"`
+	State string `json:"state"`
+}
+
+func newConnection(id string, opts options, timeline *connTimeline) *connection {
+	return &connection{
+		id:     id,
+		opts:   opts,
+		client: &client{timeline: timeline},
+		loaded: make(chan struct{}),
+	}
+}
+
+func (c *connection) setLoaded() {
+	c.loadedOnce.Do(func() { close(c.loaded) })
+}
+
+func (c *connection) files() *protoregistry.Files {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.protofiles
+}
+
+func (c *connection) setFiles(files *protoregistry.Files) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.protofiles = files
+	c.symbols = nil
+	c.validator = nil
+}
+
+// symbolIndex returns the search index of the proto files, or nil if there
+// are none loaded
+func (c *connection) symbolIndex() *symbolIndex {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.symbols == nil && c.protofiles != nil {
+		c.symbols = newSymbolIndex(c.protofiles)
+	}
+	return c.symbols
+}
+
+// requestValidator returns the validator of the proto files, or nil if there
+// are none loaded
+func (c *connection) requestValidator() *validator {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.validator == nil && c.protofiles != nil {
+		c.validator = newValidator(c.protofiles)
+	}
+	return c.validator
+}
+
+// takeImported returns the imported call to select, only the first time
+func (c *connection) takeImported() *importedCommand {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	imp := c.imported
+	c.imported = nil
+	return imp
+}
+
+func (c *connection) state() connectivity.State {
+	c.mu.Lock()
+	closed := c.closed
+	c.mu.Unlock()
+	if closed || c.client == nil {
+		return connectivity.Shutdown
+	}
+	if c.client.http != nil {
+		return connectivity.Ready
+	}
+	conn := c.client.grpcConn()
+	if conn == nil {
+		return connectivity.Connecting
+	}
+	return conn.GetState()
+}
+
+func (c *connection) methodDesc(fullname string) (protoreflect.MethodDescriptor, error) {
+	files := c.files()
+	if files == nil {
+		return nil, fmt.Errorf("no proto files loaded")
+	}
+	if !strings.HasPrefix(fullname, "/") {
+		return nil, fmt.Errorf("invalid method name: %q", fullname)
+	}
+
+	name := strings.Replace(fullname[1:], "/", ".", 1)
+	desc, err := files.FindDescriptorByName(protoreflect.FullName(name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find descriptor: %v", err)
+	}
+
+	methodDesc, ok := desc.(protoreflect.MethodDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("descriptor was not a method: %T", desc)
+	}
+
+	return methodDesc, nil
+}
+
+func (c *connection) close() error {
+	c.mu.Lock()
+	c.closed = true
+	c.mu.Unlock()
+	c.setLoaded()
+	if c.cancelMonitoring != nil {
+		c.cancelMonitoring()
+	}
+	return c.client.close()
+}
+
+// connManager keeps a connection open per workspace, so switching between
+// workspaces doesn't interrupt calls that are still in flight.
+type connManager struct {
+	mu    sync.Mutex
+	conns map[string]*connection
+}
+
+func newConnManager() *connManager {
+	return &connManager{conns: make(map[string]*connection)}
+}
+
+func (m *connManager) get(id string) *connection {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.conns[id]
+}
+
+// put adds the connection for the workspace, closing any previous one
+func (m *connManager) put(c *connection) error {
+	m.mu.Lock()
+	old := m.conns[c.id]
+	m.conns[c.id] = c
+	m.mu.Unlock()
+
+	if old != nil && old != c {
+		return old.close()
+	}
+	return nil
+}
+
+// claim returns the open connection of the workspace, or stores and returns
+// the connection from newConn if there is none, with created set. The lookup
+// and store are done under the lock, so only one caller dials.
+func (m *connManager) claim(id string, newConn func() *connection) (c *connection, created bool) {
+	m.mu.Lock()
+	old := m.conns[id]
+	if old != nil && old.state() != connectivity.Shutdown {
+		m.mu.Unlock()
+		return old, false
+	}
+	c = newConn()
+	m.conns[id] = c
+	m.mu.Unlock()
+
+	if old != nil {
+		old.close()
+	}
+	return c, true
+}
+
+// remove closes and removes the connection for the workspace, if it is
+// still the given connection, or any connection if c is nil
+func (m *connManager) remove(id string, c *connection) error {
+	m.mu.Lock()
+	cur := m.conns[id]
+	if cur == nil || (c != nil && cur != c) {
+		m.mu.Unlock()
+		return nil
+	}
+	delete(m.conns, id)
+	m.mu.Unlock()
+
+	return cur.close()
+}
+
+func (m *connManager) list() []*connection {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var conns []*connection
+	for _, c := range m.conns {
+		conns = append(conns, c)
+	}
+	return conns
+}
+
+func (m *connManager) closeAll() {
+	m.mu.Lock()
+	conns := m.conns
+	m.conns = make(map[string]*connection)
+	m.mu.Unlock()
+
+	for _, c := range conns {
+		c.close()
+	}
+}
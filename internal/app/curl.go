@@ -0,0 +1,722 @@
+package app
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/google/shlex"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+const grpcContentType = "application/grpc"
+
+// curlCommand returns a curl command for the call, with the content type and
+// framing of the workspace's transport. Requests that aren't text are
+// written with printf and piped to curl.
+func curlCommand(opts options, md protoreflect.MethodDescriptor, method string, rawJSON []byte, hs headers) (string, error) {
+	base, err := httpBaseURL(opts)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := opts.requestFormat().decode(md.Input(), rawJSON)
+	if err != nil {
+		return "", fmt.Errorf("failed to unmarshal request: %v", err)
+	}
+	data, err := proto.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+
+	var flags []string
+	setHeader := func(k, v string) {
+		flags = append(flags, "-H "+shellQuote(k+": "+v))
+	}
+	streaming := md.IsStreamingClient() || md.IsStreamingServer()
+
+	var body []byte
+	text := false
+	switch opts.Transport {
+	case transportConnect:
+		codec := opts.Codec
+		if codec == "" {
+			codec = codecProto
+		}
+		if codec == codecJSON {
+			if data, err = protojson.Marshal(req); err != nil {
+				return "", err
+			}
+			text = true
+		}
+		body = data
+		contentType := "application/" + codec
+		if streaming {
+			var env bytes.Buffer
+			writeEnvelope(&env, 0, data)
+			body = env.Bytes()
+			text = false
+			contentType = "application/connect+" + codec
+		}
+		setHeader("Content-Type", contentType)
+		setHeader("Connect-Protocol-Version", connectProtocolVersion)
+		if opts.Timeout > 0 {
+			setHeader("Connect-Timeout-Ms", strconv.FormatInt(seconds(opts.Timeout).Milliseconds(), 10))
+		}
+	case transportGRPCWeb, transportGRPCWebText:
+		var env bytes.Buffer
+		writeEnvelope(&env, 0, data)
+		body = env.Bytes()
+		contentType := grpcWebContentType
+		if opts.Transport == transportGRPCWebText {
+			body = []byte(base64.StdEncoding.EncodeToString(body))
+			text = true
+			contentType = grpcWebTextContentType
+		}
+		setHeader("Content-Type", contentType)
+		setHeader("Accept", contentType)
+		setHeader("X-Grpc-Web", "1")
+		if opts.Timeout > 0 {
+			setHeader("Grpc-Timeout", encodeTimeout(seconds(opts.Timeout)))
+		}
+	default:
+		var env bytes.Buffer
+		writeEnvelope(&env, 0, data)
+		body = env.Bytes()
+		if opts.Plaintext {
+			flags = append(flags, "--http2-prior-knowledge")
+		} else {
+			flags = append(flags, "--http2")
+		}
+		setHeader("Content-Type", grpcContentType)
+		setHeader("TE", "trailers")
+		if opts.Timeout > 0 {
+			setHeader("Grpc-Timeout", encodeTimeout(seconds(opts.Timeout)))
+		}
+	}
+
+	for _, h := range hs {
+		if h.Key == "" {
+			continue
+		}
+		val := h.Val
+		if strings.HasSuffix(strings.ToLower(h.Key), "-bin") {
+			val = base64.StdEncoding.EncodeToString([]byte(val))
+		}
+		setHeader(h.Key, val)
+	}
+
+	if opts.Insecure {
+		flags = append(flags, "--insecure")
+	}
+	if opts.Timeout > 0 {
+		flags = append(flags, "--max-time "+strconv.FormatFloat(opts.Timeout, 'g', -1, 64))
+	}
+
+	var sb strings.Builder
+	if text {
+		flags = append(flags, "--data-raw "+shellQuote(string(body)))
+	} else {
+		sb.WriteString("printf ")
+		sb.WriteString(printfQuote(body))
+		sb.WriteString(" | ")
+		// Binary responses are only written to the terminal if asked to
+		flags = append(flags, "--data-binary @-", "--output -")
+	}
+	sb.WriteString("curl -X POST \\\n")
+	for _, f := range flags {
+		sb.WriteString("    ")
+		sb.WriteString(f)
+		sb.WriteString(" \\\n")
+	}
+	sb.WriteString("    ")
+	sb.WriteString(shellQuote(base + method))
+	return sb.String(), nil
+}
+
+// shellQuote single quotes s for a POSIX shell
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// printfQuote returns a single quoted printf format that writes b, with any
+// byte that isn't printable ASCII as an octal escape
+func printfQuote(b []byte) string {
+	var sb strings.Builder
+	sb.WriteByte('\'')
+	for _, c := range b {
+		switch {
+		case c == '%':
+			sb.WriteString("%%")
+		case c == '\\':
+			sb.WriteString(`\\`)
+		case c == '\'' || c < ' ' || c > '~':
+			fmt.Fprintf(&sb, `\%03o`, c)
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	sb.WriteByte('\'')
+	return sb.String()
+}
+
+// unprintf returns what printf writes for a format without verbs, as
+// written by printfQuote
+func unprintf(format string) ([]byte, error) {
+	var b []byte
+	for i := 0; i < len(format); i++ {
+		c := format[i]
+		switch {
+		case c == '%' && i+1 < len(format) && format[i+1] == '%':
+			b = append(b, '%')
+			i++
+		case c == '%':
+			return nil, errors.New("printf formats with verbs are not supported")
+		case c == '\\' && i+1 < len(format):
+			i++
+			switch e := format[i]; {
+			case e >= '0' && e <= '7':
+				n, j := 0, i
+				for ; j < len(format) && j < i+3 && format[j] >= '0' && format[j] <= '7'; j++ {
+					n = n*8 + int(format[j]-'0')
+				}
+				b = append(b, byte(n))
+				i = j - 1
+			case e == 'n':
+				b = append(b, '\n')
+			case e == 't':
+				b = append(b, '\t')
+			case e == 'r':
+				b = append(b, '\r')
+			default:
+				b = append(b, e)
+			}
+		default:
+			b = append(b, c)
+		}
+	}
+	return b, nil
+}
+
+type curlArguments struct {
+	URL      string
+	Request  string
+	Headers  headers
+	Data     []byte
+	HasData  bool
+	Insecure bool
+	CACert   string
+	Cert     string
+	Key      string
+
+	// Durations are in seconds, as with curl
+	MaxTime        float64
+	ConnectTimeout float64
+}
+
+// curlFlags are the curl options that are understood, by their long name
+var curlFlags = map[string]struct {
+	short    string
+	hasValue bool
+}{
+	"request":               {"X", true},
+	"header":                {"H", true},
+	"data":                  {"d", true},
+	"data-raw":              {"", true},
+	"data-binary":           {"", true},
+	"data-ascii":            {"", true},
+	"url":                   {"", true},
+	"user":                  {"u", true},
+	"insecure":              {"k", false},
+	"cacert":                {"", true},
+	"cert":                  {"E", true},
+	"key":                   {"", true},
+	"max-time":              {"m", true},
+	"connect-timeout":       {"", true},
+	"user-agent":            {"A", true},
+	"output":                {"o", true},
+	"silent":                {"s", false},
+	"show-error":            {"S", false},
+	"verbose":               {"v", false},
+	"include":               {"i", false},
+	"location":              {"L", false},
+	"no-buffer":             {"N", false},
+	"fail":                  {"f", false},
+	"globoff":               {"g", false},
+	"compressed":            {"", false},
+	"raw":                   {"", false},
+	"http2":                 {"", false},
+	"http2-prior-knowledge": {"", false},
+	"http1.1":               {"", false},
+	"no-progress-meter":     {"", false},
+}
+
+func curlFlagByShort(c byte) (string, bool) {
+	for name, f := range curlFlags {
+		if f.short == string(c) {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// curlData returns the data of a --data flag, reading it from a file if it
+// starts with @, as curl does for all but --data-raw
+func curlData(name, val string, stdin []byte) ([]byte, error) {
+	if name == "data-raw" || !strings.HasPrefix(val, "@") {
+		return []byte(val), nil
+	}
+	if val == "@-" {
+		if stdin == nil {
+			return nil, errors.New("reading the request data from stdin is only supported from printf")
+		}
+		return stdin, nil
+	}
+	return os.ReadFile(val[1:])
+}
+
+func parseCurlCommand(command string) (*curlArguments, error) {
+	split, err := shlex.Split(command)
+	if err != nil {
+		return nil, err
+	}
+	var args []string
+	for _, arg := range split {
+		// Line continuations are left as empty arguments
+		if strings.TrimSpace(arg) != "" {
+			args = append(args, arg)
+		}
+	}
+
+	// Binary requests, such as those exported, are piped in from printf
+	var stdin []byte
+	if len(args) >= 3 && args[0] == "printf" && args[2] == "|" {
+		if stdin, err = unprintf(args[1]); err != nil {
+			return nil, err
+		}
+		args = args[3:]
+	}
+
+	if len(args) == 0 {
+		return nil, errors.New("empty curl command")
+	}
+	if strings.ToLower(args[0]) != "curl" {
+		return nil, errors.New("invalid curl command: must start with 'curl'")
+	}
+
+	var ca curlArguments
+	var data [][]byte
+	set := func(name, val string) error {
+		switch name {
+		case "request":
+			ca.Request = strings.ToUpper(val)
+		case "header":
+			h, err := parseHeaderLine(val)
+			if err != nil {
+				return err
+			}
+			ca.Headers = append(ca.Headers, h)
+		case "data", "data-raw", "data-binary", "data-ascii":
+			d, err := curlData(name, val, stdin)
+			if err != nil {
+				return err
+			}
+			if name != "data-binary" {
+				// curl strips the newlines of data read from files
+				d = bytes.ReplaceAll(bytes.ReplaceAll(d, []byte("\r"), nil), []byte("\n"), nil)
+			}
+			data = append(data, d)
+		case "url":
+			ca.URL = val
+		case "user":
+			ca.Headers = append(ca.Headers, header{
+				Key: "authorization",
+				Val: "Basic " + base64.StdEncoding.EncodeToString([]byte(val)),
+			})
+		case "insecure":
+			ca.Insecure = true
+		case "cacert":
+			ca.CACert = val
+		case "cert":
+			ca.Cert = val
+		case "key":
+			ca.Key = val
+		case "max-time", "connect-timeout":
+			secs, err := strconv.ParseFloat(val, 64)
+			if err != nil {
+				return fmt.Errorf("invalid --%s: %v", name, err)
+			}
+			if name == "max-time" {
+				ca.MaxTime = secs
+			} else {
+				ca.ConnectTimeout = secs
+			}
+		}
+		return nil
+	}
+
+	for i := 1; i < len(args); i++ {
+		arg := args[i]
+		value := func(name string) (string, error) {
+			if i+1 >= len(args) {
+				return "", fmt.Errorf("option --%s needs a value", name)
+			}
+			i++
+			return args[i], nil
+		}
+
+		switch {
+		case strings.HasPrefix(arg, "--"):
+			name := arg[2:]
+			f, ok := curlFlags[name]
+			if !ok {
+				return nil, fmt.Errorf("unsupported curl option %s", arg)
+			}
+			val := ""
+			if f.hasValue {
+				if val, err = value(name); err != nil {
+					return nil, err
+				}
+			}
+			if err := set(name, val); err != nil {
+				return nil, err
+			}
+		case strings.HasPrefix(arg, "-") && len(arg) > 1:
+			// Short options can be combined, with the value of the last
+			// either attached or as the next argument
+			for j := 1; j < len(arg); j++ {
+				name, ok := curlFlagByShort(arg[j])
+				if !ok {
+					return nil, fmt.Errorf("unsupported curl option -%c", arg[j])
+				}
+				val := ""
+				if curlFlags[name].hasValue {
+					if val = arg[j+1:]; val == "" {
+						if val, err = value(name); err != nil {
+							return nil, err
+						}
+					}
+					j = len(arg)
+				}
+				if err := set(name, val); err != nil {
+					return nil, err
+				}
+			}
+		default:
+			if ca.URL != "" {
+				return nil, errors.New("invalid curl command: only a single URL is supported")
+			}
+			ca.URL = arg
+		}
+	}
+
+	if ca.URL == "" {
+		return nil, errors.New("invalid curl command: no URL")
+	}
+	if len(data) > 0 {
+		ca.HasData = true
+		ca.Data = bytes.Join(data, []byte("&"))
+	}
+	if ca.Request == "" {
+		ca.Request = "GET"
+		if ca.HasData {
+			ca.Request = "POST"
+		}
+	}
+	if (ca.Cert == "") != (ca.Key == "") {
+		return nil, errors.New("--cert and --key must be used together")
+	}
+	return &ca, nil
+}
+
+// curlProtocolHeader reports if the header is set by the transport, rather
+// than being metadata of the call
+func curlProtocolHeader(k string) bool {
+	if reservedHeader(k) {
+		return true
+	}
+	switch k {
+	case "accept", "accept-encoding", "content-length", "host", "x-grpc-web", "x-user-agent":
+		return true
+	}
+	return strings.HasPrefix(k, "connect-")
+}
+
+// curlCall is the transport, method and request of a curl command
+type curlCall struct {
+	transport string
+	codec     string
+	method    string
+	base      *url.URL
+	json      string
+	payload   []byte
+}
+
+// parseCurlCall works out the protocol of the request from its content type,
+// or its query for Connect GET requests, and unframes its body
+func parseCurlCall(ca *curlArguments) (*curlCall, error) {
+	u, err := url.Parse(ca.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL: %v", err)
+	}
+	if u.Scheme == "" {
+		// curl defaults to http for URLs without a scheme
+		if u, err = url.Parse("http://" + ca.URL); err != nil {
+			return nil, fmt.Errorf("invalid URL: %v", err)
+		}
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported scheme %q", u.Scheme)
+	}
+
+	path := strings.TrimSuffix(u.Path, "/")
+	svc := strings.LastIndex(path, "/")
+	if svc > 0 {
+		svc = strings.LastIndex(path[:svc], "/")
+	}
+	if svc < 0 || !strings.Contains(path[svc+1:], ".") {
+		return nil, fmt.Errorf("URL path %q doesn't end with a method, such as /package.Service/Method", u.Path)
+	}
+	cc := &curlCall{method: path[svc:]}
+	cc.base = &url.URL{Scheme: u.Scheme, Host: u.Host, Path: path[:svc]}
+
+	var contentType string
+	for _, h := range ca.Headers {
+		if h.Key == "content-type" {
+			contentType = h.Val
+		}
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	body := ca.Data
+
+	if ca.Request == "GET" {
+		// A Connect unary GET has the message in its query
+		q := u.Query()
+		if q.Get("message") == "" {
+			return nil, errors.New("a GET request must be a Connect request with the message in its query")
+		}
+		mediaType = "application/" + q.Get("encoding")
+		body = []byte(q.Get("message"))
+		if q.Get("base64") == "1" {
+			if body, err = base64.RawURLEncoding.DecodeString(strings.TrimRight(q.Get("message"), "=")); err != nil {
+				return nil, fmt.Errorf("invalid message: %v", err)
+			}
+		}
+	} else if ca.Request != "POST" {
+		return nil, fmt.Errorf("unsupported request method %s", ca.Request)
+	}
+
+	unframe := func(b []byte) ([]byte, error) {
+		if len(b) == 0 {
+			return nil, nil
+		}
+		flags, data, err := readEnvelope(bytes.NewReader(b))
+		if err != nil {
+			return nil, err
+		}
+		if flags&grpcWebFlagCompressed != 0 {
+			return nil, errors.New("compressed requests are not supported")
+		}
+		return data, nil
+	}
+
+	switch {
+	case mediaType == "application/json" || mediaType == "":
+		cc.transport, cc.codec, cc.json = transportConnect, codecJSON, string(body)
+	case mediaType == "application/proto":
+		cc.transport, cc.codec, cc.payload = transportConnect, codecProto, body
+	case mediaType == "application/connect+json":
+		cc.transport, cc.codec = transportConnect, codecJSON
+		data, err := unframe(body)
+		if err != nil {
+			return nil, err
+		}
+		cc.json = string(data)
+	case mediaType == "application/connect+proto":
+		cc.transport, cc.codec = transportConnect, codecProto
+		if cc.payload, err = unframe(body); err != nil {
+			return nil, err
+		}
+	case strings.HasPrefix(mediaType, "application/grpc-web-text"):
+		cc.transport = transportGRPCWebText
+		decoded, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(body)))
+		if err != nil {
+			return nil, fmt.Errorf("invalid grpc-web-text request: %v", err)
+		}
+		if cc.payload, err = unframe(decoded); err != nil {
+			return nil, err
+		}
+	case strings.HasPrefix(mediaType, "application/grpc-web"):
+		cc.transport = transportGRPCWeb
+		if cc.payload, err = unframe(body); err != nil {
+			return nil, err
+		}
+	case strings.HasPrefix(mediaType, grpcContentType):
+		cc.transport = transportGRPC
+		if cc.payload, err = unframe(body); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported content type %q", contentType)
+	}
+	if cc.payload == nil && cc.json == "" {
+		cc.json = "{}"
+	}
+	return cc, nil
+}
+
+// options applies the connection of the curl command to the options of a
+// workspace, reading the cert files it refers to
+func (cc *curlCall) options(ca *curlArguments, opts options) (options, error) {
+	opts.Transport = cc.transport
+	opts.Codec = cc.codec
+	// The request is imported as JSON
+	opts.InputFormat = formatJSON
+	opts.Plaintext = cc.base.Scheme == "http"
+	opts.Insecure = ca.Insecure
+	opts.Addr = cc.base.Host
+	if cc.transport == transportGRPC {
+		opts.Transport = ""
+		if cc.base.Port() == "" {
+			port := "443"
+			if opts.Plaintext {
+				port = "80"
+			}
+			opts.Addr = net.JoinHostPort(cc.base.Hostname(), port)
+		}
+		// Unless proto files are already set up
+		opts.Reflect = len(opts.Protos.Files) == 0 && len(opts.Protos.Protosets) == 0
+	} else {
+		if cc.base.Path != "" {
+			// Proxies may serve under a path prefix
+			opts.Addr = cc.base.String()
+		}
+		// Reflection isn't available over HTTP transports
+		opts.Reflect = false
+	}
+
+	var err error
+	if opts.Rootca, err = readFlagFile("--cacert", ca.CACert); err != nil {
+		return opts, err
+	}
+	if opts.Clientcert, err = readFlagFile("--cert", ca.Cert); err != nil {
+		return opts, err
+	}
+	if opts.Clientkey, err = readFlagFile("--key", ca.Key); err != nil {
+		return opts, err
+	}
+
+	if ca.MaxTime > 0 {
+		opts.Timeout = ca.MaxTime
+	}
+	for _, h := range ca.Headers {
+		if h.Key == "connect-timeout-ms" && ca.MaxTime == 0 {
+			if ms, err := strconv.ParseFloat(h.Val, 64); err == nil {
+				opts.Timeout = ms / 1000
+			}
+		}
+	}
+	if ca.ConnectTimeout > 0 {
+		opts.ConnectTimeout = ca.ConnectTimeout
+	}
+	return opts, nil
+}
+
+// importCurl returns the workspace and call of the curl command. The
+// workspace is the first one of the same transport, URL and settings, if
+// there is one, or else a new one.
+func (a *api) importCurl(command string) (*importedCommand, error) {
+	ca, err := parseCurlCommand(command)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing curl command: %v", err)
+	}
+	cc, err := parseCurlCall(ca)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing curl command: %v", err)
+	}
+
+	workspaces, err := a.ListWorkspaces()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workspaces: %v", err)
+	}
+	opts, err := importWorkspace(workspaces, func(ws options) bool {
+		if ws.Addr == "" || isHTTPTransport(ws.Transport) != isHTTPTransport(cc.transport) {
+			return false
+		}
+		u, err := httpBaseURL(ws)
+		return err == nil && u == cc.base.String()
+	}, func(ws options) (options, error) {
+		return cc.options(ca, ws)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var md headers
+	for _, h := range ca.Headers {
+		if curlProtocolHeader(h.Key) {
+			continue
+		}
+		if strings.HasSuffix(h.Key, "-bin") {
+			b, err := decodeBinHeader(h.Val)
+			if err != nil {
+				return nil, fmt.Errorf("invalid binary header %s: %v", h.Key, err)
+			}
+			h.Val = string(b)
+		}
+		md = append(md, h)
+	}
+
+	return &importedCommand{
+		Workspace: opts,
+		Method:    cc.method,
+		Data:      cc.json,
+		Metadata:  md,
+		payload:   cc.payload,
+	}, nil
+}
+
+// resolve converts the binary request of the import to JSON, or generates
+// an example request, now that the proto files of the connection are loaded
+func (imp *importedCommand) resolve(conn *connection) error {
+	if imp.payload == nil && !imp.template {
+		return nil
+	}
+	md, err := conn.methodDesc(imp.Method)
+	if err != nil {
+		return err
+	}
+	if imp.template {
+		data, err := generateExample(conn.files(), md.Input(), exampleOptions{})
+		if err != nil {
+			return err
+		}
+		// In the format of the command's data
+		if imp.Data, err = conn.opts.requestFormat().fromJSON(md.Input(), data); err != nil {
+			return err
+		}
+		imp.template = false
+		return nil
+	}
+	req := dynamicpb.NewMessage(md.Input())
+	if err := proto.Unmarshal(imp.payload, req); err != nil {
+		return fmt.Errorf("failed to decode imported request: %v", err)
+	}
+	b, err := protojson.Marshal(req)
+	if err != nil {
+		return err
+	}
+	imp.Data = string(b)
+	imp.payload = nil
+	return nil
+}
@@ -0,0 +1,495 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// diffOptions change how messages are compared.
+//
+// Ignore holds field paths to skip, e.g. "items.created_at", which matches
+// the field of every element of items. A name without a dot, such as
+// "request_id", matches that field at any depth.
+//
+// With IgnoreOrder repeated fields are compared as sets rather than by
+// index. With Presence a field that is set to its default value differs from
+// one that isn't set, which otherwise compare equal as they do on the wire.
+type diffOptions struct {
+	Ignore      []string `json:"ignore"`
+	IgnoreOrder bool     `json:"ignore_order" mapstructure:"ignore_order"`
+	Presence    bool     `json:"presence"`
+}
+
+// diffEntry is a single difference at a field path such as
+// "features[2].location.latitude" or `labels["env"]`
+type diffEntry struct {
+	Path string `json:"path"`
+	Old  string `json:"old"`
+	New  string `json:"new"`
+}
+
+type messageDiff struct {
+	Equal   bool        `json:"equal"`
+	Added   []diffEntry `json:"added"`
+	Removed []diffEntry `json:"removed"`
+	Changed []diffEntry `json:"changed"`
+}
+
+type differ struct {
+	opts diffOptions
+	res  *messageDiff
+}
+
+// diffMessages compares two messages field by field. The messages may come
+// from different registries, e.g. two workspaces, so fields are matched by
+// number rather than by descriptor.
+func diffMessages(a, b proto.Message, opts diffOptions) *messageDiff {
+	d := &differ{opts: opts, res: &messageDiff{}}
+	d.message("", a.ProtoReflect(), b.ProtoReflect())
+	d.res.Equal = len(d.res.Added) == 0 && len(d.res.Removed) == 0 && len(d.res.Changed) == 0
+	return d.res
+}
+
+func (d *differ) ignored(path string) bool {
+	if len(d.opts.Ignore) == 0 {
+		return false
+	}
+	plain := stripIndexes(path)
+	name := plain[strings.LastIndex(plain, ".")+1:]
+	for _, ig := range d.opts.Ignore {
+		if ig == path || ig == plain || (!strings.Contains(ig, ".") && ig == name) {
+			return true
+		}
+	}
+	return false
+}
+
+// stripIndexes removes the list indexes and map keys from a field path
+func stripIndexes(path string) string {
+	var b strings.Builder
+	depth := 0
+	inString := false
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		switch {
+		case inString:
+			if c == '\\' {
+				i++
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"' && depth > 0:
+			inString = true
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+		case depth == 0:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+func joinPath(parent string, fd protoreflect.FieldDescriptor) string {
+	name := string(fd.Name())
+	if fd.IsExtension() {
+		name = "(" + string(fd.FullName()) + ")"
+	}
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
+
+func (d *differ) message(path string, a, b protoreflect.Message) {
+	fields := map[protoreflect.FieldNumber]protoreflect.FieldDescriptor{}
+	collect := func(m protoreflect.Message) {
+		fds := m.Descriptor().Fields()
+		for i := 0; i < fds.Len(); i++ {
+			if _, ok := fields[fds.Get(i).Number()]; !ok {
+				fields[fds.Get(i).Number()] = fds.Get(i)
+			}
+		}
+		m.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+			if fd.IsExtension() {
+				fields[fd.Number()] = fd
+			}
+			return true
+		})
+	}
+	collect(a)
+	collect(b)
+
+	nums := make([]int, 0, len(fields))
+	for n := range fields {
+		nums = append(nums, int(n))
+	}
+	sort.Ints(nums)
+
+	for _, n := range nums {
+		fd := fields[protoreflect.FieldNumber(n)]
+		fp := joinPath(path, fd)
+		if d.ignored(fp) {
+			continue
+		}
+		afd, bfd := fieldByNumber(a, fd), fieldByNumber(b, fd)
+		d.field(fp, fd, a, afd, b, bfd)
+	}
+}
+
+func fieldByNumber(m protoreflect.Message, fd protoreflect.FieldDescriptor) protoreflect.FieldDescriptor {
+	if fd.IsExtension() {
+		if m.Has(fd) {
+			return fd
+		}
+		return nil
+	}
+	f := m.Descriptor().Fields().ByNumber(fd.Number())
+	if f == nil || f.Kind() != fd.Kind() || f.Cardinality() != fd.Cardinality() {
+		return nil
+	}
+	return f
+}
+
+func (d *differ) field(path string, fd protoreflect.FieldDescriptor, a protoreflect.Message, afd protoreflect.FieldDescriptor, b protoreflect.Message, bfd protoreflect.FieldDescriptor) {
+	aHas := afd != nil && a.Has(afd)
+	bHas := bfd != nil && b.Has(bfd)
+
+	switch {
+	case fd.IsList():
+		var al, bl protoreflect.List
+		if aHas {
+			al = a.Get(afd).List()
+		}
+		if bHas {
+			bl = b.Get(bfd).List()
+		}
+		d.list(path, fd, al, bl)
+		return
+	case fd.IsMap():
+		var am, bm protoreflect.Map
+		if aHas {
+			am = a.Get(afd).Map()
+		}
+		if bHas {
+			bm = b.Get(bfd).Map()
+		}
+		d.mapField(path, fd, am, bm)
+		return
+	}
+
+	if !aHas && !bHas {
+		return
+	}
+	if !d.opts.Presence && (!aHas || !bHas) {
+		// Unset is the same as the default value on the wire. An unset
+		// message is compared to an empty one.
+		if fd.Message() != nil {
+			if !aHas {
+				if isEmptyMessage(b.Get(bfd).Message()) {
+					return
+				}
+			} else if isEmptyMessage(a.Get(afd).Message()) {
+				return
+			}
+		} else {
+			var v protoreflect.Value
+			if aHas {
+				v = a.Get(afd)
+			} else {
+				v = b.Get(bfd)
+			}
+			if scalarEqual(fd, v, fd.Default()) {
+				return
+			}
+			if aHas {
+				d.change(path, fd, a.Get(afd), fd.Default())
+			} else {
+				d.change(path, fd, fd.Default(), b.Get(bfd))
+			}
+			return
+		}
+	}
+	if !aHas {
+		d.res.Added = append(d.res.Added, diffEntry{Path: path, New: formatValue(fd, b.Get(bfd))})
+		return
+	}
+	if !bHas {
+		d.res.Removed = append(d.res.Removed, diffEntry{Path: path, Old: formatValue(fd, a.Get(afd))})
+		return
+	}
+	d.value(path, fd, a.Get(afd), b.Get(bfd))
+}
+
+func (d *differ) value(path string, fd protoreflect.FieldDescriptor, av, bv protoreflect.Value) {
+	if fd.Message() != nil {
+		d.message(path, av.Message(), bv.Message())
+		return
+	}
+	if !scalarEqual(fd, av, bv) {
+		d.change(path, fd, av, bv)
+	}
+}
+
+func (d *differ) change(path string, fd protoreflect.FieldDescriptor, av, bv protoreflect.Value) {
+	d.res.Changed = append(d.res.Changed, diffEntry{
+		Path: path,
+		Old:  formatValue(fd, av),
+		New:  formatValue(fd, bv),
+	})
+}
+
+func (d *differ) list(path string, fd protoreflect.FieldDescriptor, a, b protoreflect.List) {
+	alen, blen := 0, 0
+	if a != nil {
+		alen = a.Len()
+	}
+	if b != nil {
+		blen = b.Len()
+	}
+	elem := func(i int) string { return fmt.Sprintf("%s[%d]", path, i) }
+
+	if d.opts.IgnoreOrder {
+		matched := make([]bool, blen)
+		for i := 0; i < alen; i++ {
+			found := false
+			for j := 0; j < blen; j++ {
+				if !matched[j] && d.equal(elem(i), fd, a.Get(i), b.Get(j)) {
+					matched[j] = true
+					found = true
+					break
+				}
+			}
+			if !found {
+				d.res.Removed = append(d.res.Removed, diffEntry{Path: elem(i), Old: formatValue(fd, a.Get(i))})
+			}
+		}
+		for j := 0; j < blen; j++ {
+			if !matched[j] {
+				d.res.Added = append(d.res.Added, diffEntry{Path: elem(j), New: formatValue(fd, b.Get(j))})
+			}
+		}
+		return
+	}
+
+	for i := 0; i < alen || i < blen; i++ {
+		switch {
+		case i >= blen:
+			d.res.Removed = append(d.res.Removed, diffEntry{Path: elem(i), Old: formatValue(fd, a.Get(i))})
+		case i >= alen:
+			d.res.Added = append(d.res.Added, diffEntry{Path: elem(i), New: formatValue(fd, b.Get(i))})
+		default:
+			d.value(elem(i), fd, a.Get(i), b.Get(i))
+		}
+	}
+}
+
+func (d *differ) mapField(path string, fd protoreflect.FieldDescriptor, a, b protoreflect.Map) {
+	keys := map[string]protoreflect.MapKey{}
+	collect := func(m protoreflect.Map) {
+		if m == nil {
+			return
+		}
+		m.Range(func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+			keys[k.String()] = k
+			return true
+		})
+	}
+	collect(a)
+	collect(b)
+
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	vfd := fd.MapValue()
+	for _, ks := range sorted {
+		k := keys[ks]
+		kp := fmt.Sprintf("%s[%s]", path, formatValue(fd.MapKey(), k.Value()))
+		aHas := a != nil && a.Has(k)
+		bHas := b != nil && b.Has(k)
+		switch {
+		case !aHas:
+			d.res.Added = append(d.res.Added, diffEntry{Path: kp, New: formatValue(vfd, b.Get(k))})
+		case !bHas:
+			d.res.Removed = append(d.res.Removed, diffEntry{Path: kp, Old: formatValue(vfd, a.Get(k))})
+		default:
+			d.value(kp, vfd, a.Get(k), b.Get(k))
+		}
+	}
+}
+
+// equal reports if two values have no differences under the same options
+func (d *differ) equal(path string, fd protoreflect.FieldDescriptor, av, bv protoreflect.Value) bool {
+	sub := &differ{opts: d.opts, res: &messageDiff{}}
+	sub.value(path, fd, av, bv)
+	return len(sub.res.Added) == 0 && len(sub.res.Removed) == 0 && len(sub.res.Changed) == 0
+}
+
+func isEmptyMessage(m protoreflect.Message) bool {
+	empty := true
+	m.Range(func(protoreflect.FieldDescriptor, protoreflect.Value) bool {
+		empty = false
+		return false
+	})
+	return empty
+}
+
+func scalarEqual(fd protoreflect.FieldDescriptor, a, b protoreflect.Value) bool {
+	if fd.Kind() == protoreflect.BytesKind {
+		return string(a.Bytes()) == string(b.Bytes())
+	}
+	return a.Interface() == b.Interface()
+}
+
+// formatValue formats a value for display, messages as JSON
+func formatValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) string {
+	switch {
+	case fd.Message() != nil:
+		if m, ok := v.Interface().(protoreflect.Message); ok {
+			b, err := protojson.Marshal(m.Interface())
+			if err != nil {
+				return err.Error()
+			}
+			return string(b)
+		}
+	case fd.Kind() == protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			return string(ev.Name())
+		}
+		return fmt.Sprint(v.Enum())
+	case fd.Kind() == protoreflect.StringKind:
+		return fmt.Sprintf("%q", v.String())
+	case fd.Kind() == protoreflect.BytesKind:
+		return fmt.Sprintf("%q", v.Bytes())
+	}
+	return v.String()
+}
+
+// decodeMessage decodes JSON into a new message of the descriptor
+func decodeMessage(md protoreflect.MessageDescriptor, data string) (proto.Message, error) {
+	m := dynamicpb.NewMessage(md)
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal([]byte(data), m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// historyDiff compares the responses and status of two recorded calls
+type historyDiff struct {
+	LeftStatus  string         `json:"left_status"`
+	RightStatus string         `json:"right_status"`
+	Equal       bool           `json:"equal"`
+	Messages    []responseDiff `json:"messages"`
+}
+
+// responseDiff is the diff of the responses at the same index of two calls.
+// Missing is "left" or "right" if only one call has a response there.
+type responseDiff struct {
+	Index   int          `json:"index"`
+	Missing string       `json:"missing"`
+	Diff    *messageDiff `json:"diff"`
+}
+
+// diffResponses compares recorded responses by index. Each side is decoded
+// with its own descriptor, as they may come from different workspaces.
+func diffResponses(leftMD, rightMD protoreflect.MessageDescriptor, left, right []recordedMessage, opts diffOptions) ([]responseDiff, bool, error) {
+	var diffs []responseDiff
+	equal := true
+	for i := 0; i < len(left) || i < len(right); i++ {
+		rd := responseDiff{Index: i}
+		switch {
+		case i >= len(left):
+			rd.Missing = "left"
+		case i >= len(right):
+			rd.Missing = "right"
+		default:
+			a, err := decodeMessage(leftMD, left[i].JSON)
+			if err != nil {
+				return nil, false, fmt.Errorf("response %d: %v", i, err)
+			}
+			b, err := decodeMessage(rightMD, right[i].JSON)
+			if err != nil {
+				return nil, false, fmt.Errorf("response %d: %v", i, err)
+			}
+			rd.Diff = diffMessages(a, b, opts)
+		}
+		if rd.Missing != "" || !rd.Diff.Equal {
+			equal = false
+		}
+		diffs = append(diffs, rd)
+	}
+	return diffs, equal, nil
+}
+
+// DiffMessages compares two JSON messages of the method on the current
+// workspace, its responses if output is true or else its requests
+func (a *api) DiffMessages(method, left, right string, output bool, rawOpts interface{}) (*messageDiff, error) {
+	var opts diffOptions
+	if err := mapstructure.Decode(rawOpts, &opts); err != nil {
+		return nil, fmt.Errorf("failed to decode diff options: %v", err)
+	}
+	md, err := a.getMethodDesc(method)
+	if err != nil {
+		return nil, err
+	}
+	desc := md.Input()
+	if output {
+		desc = md.Output()
+	}
+	l, err := decodeMessage(desc, left)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode left message: %v", err)
+	}
+	r, err := decodeMessage(desc, right)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode right message: %v", err)
+	}
+	return diffMessages(l, r, opts), nil
+}
+
+// DiffHistory compares the responses and status of two recorded calls of
+// the current workspace
+func (a *api) DiffHistory(leftID, rightID string, rawOpts interface{}) (*historyDiff, error) {
+	var opts diffOptions
+	if err := mapstructure.Decode(rawOpts, &opts); err != nil {
+		return nil, fmt.Errorf("failed to decode diff options: %v", err)
+	}
+	left, err := a.GetHistoryEntry(leftID)
+	if err != nil {
+		return nil, err
+	}
+	right, err := a.GetHistoryEntry(rightID)
+	if err != nil {
+		return nil, err
+	}
+	if left.Method != right.Method {
+		return nil, errors.New("history entries are for different methods")
+	}
+	md, err := a.getMethodDesc(left.Method)
+	if err != nil {
+		return nil, err
+	}
+
+	diffs, equal, err := diffResponses(md.Output(), md.Output(), left.messages(false), right.messages(false), opts)
+	if err != nil {
+		return nil, err
+	}
+	return &historyDiff{
+		LeftStatus:  left.Status,
+		RightStatus: right.Status,
+		Equal:       equal && left.Status == right.Status,
+		Messages:    diffs,
+	}, nil
+}
@@ -6,6 +6,8 @@ const (
 	eventClientConnectStarted  = "wombat:client_connect_started"
 	eventClientConnected       = "wombat:client_connected"
 	eventClientStateChanged    = "wombat:client_state_changed"
+	eventHealthChanged         = "wombat:health_changed"
+	eventConnectionEvent       = "wombat:connection_event"
 	eventServicesSelectChanged = "wombat:services_select_changed"
 	eventMethodInputChanged    = "wombat:method_input_changed"
 	eventRPCStarted            = "wombat:rpc_started"
@@ -24,4 +26,12 @@ const (
 	eventStatInTrailer         = "wombat:stat_in_trailer"
 	eventStatEnd               = "wombat:stat_end"
 	eventUpdateAvailable       = "wombat:update_available"
+	eventBenchProgress         = "wombat:bench_progress"
+	eventBenchEnded            = "wombat:bench_ended"
+	eventScriptStep            = "wombat:script_step"
+	eventScriptEnded           = "wombat:script_ended"
+	eventHistoryAdded          = "wombat:history_added"
+	eventReplayEnded           = "wombat:replay_ended"
+	eventFanOutEnded           = "wombat:fan_out_ended"
+	eventValidationFailed      = "wombat:validation_failed"
 )
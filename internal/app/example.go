@@ -0,0 +1,731 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"unicode"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+const (
+	exampleModeAll      = "all"
+	exampleModeRequired = "required"
+)
+
+// Custom options with the constraints of fields and oneofs
+const (
+	bufValidateField     = "buf.validate.field"
+	bufValidateOneof     = "buf.validate.oneof"
+	pgvRules             = "validate.rules"
+	pgvRequired          = "validate.required"
+	googleFieldBehavior  = "google.api.field_behavior"
+	fieldBehaviorRequire = 2 // google.api.FieldBehavior.REQUIRED
+)
+
+// exampleOptions set which fields of an example message are populated and
+// with what
+type exampleOptions struct {
+	// Mode is all (default) for every field, or required for the fields that
+	// look required: proto2 required fields, those with the REQUIRED field
+	// behavior, and those whose validation rules don't allow them to be
+	// empty.
+	Mode string `json:"mode"`
+	// Fake fills the fields with realistic values based on their name and
+	// type, rather than zero values
+	Fake bool `json:"fake"`
+	// Seed varies the fake values; the same seed gives the same example
+	Seed int64 `json:"seed"`
+}
+
+// fieldRules are the constraints of a field from buf.validate or
+// protoc-gen-validate, as far as they shape an example value
+type fieldRules struct {
+	required bool
+	constVal *protoreflect.Value
+	in       []protoreflect.Value
+	examples []protoreflect.Value
+
+	min, max                   *float64
+	minExclusive, maxExclusive bool
+	minLen, maxLen             *uint64
+	minItems                   uint64
+	format                     string
+	items, mapKeys, mapValues  *fieldRules
+}
+
+// allowsEmpty reports if the zero value of the field passes the rules
+func (r *fieldRules) allowsEmpty() bool {
+	switch {
+	case r.required, r.constVal != nil, len(r.in) > 0, r.format != "", r.minItems > 0:
+		return false
+	case r.minLen != nil && *r.minLen > 0:
+		return false
+	case r.min != nil && (*r.min > 0 || (*r.min == 0 && r.minExclusive)):
+		return false
+	case r.max != nil && (*r.max < 0 || (*r.max == 0 && r.maxExclusive)):
+		return false
+	}
+	return true
+}
+
+func numericValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) (float64, bool) {
+	switch fd.Kind() {
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return float64(v.Int()), true
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return float64(v.Uint()), true
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return v.Float(), true
+	}
+	return 0, false
+}
+
+// parseFieldRules reads the rules of a buf.validate FieldConstraints or a
+// protoc-gen-validate FieldRules message, which share their field names
+func parseFieldRules(m protoreflect.Message) *fieldRules {
+	r := &fieldRules{}
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.Name() == "required" && fd.Kind() == protoreflect.BoolKind:
+			r.required = v.Bool()
+		case fd.Name() == "message" && fd.Message() != nil:
+			// protoc-gen-validate has the required rule of messages here
+			if req := fd.Message().Fields().ByName("required"); req != nil && v.Message().Get(req).Bool() {
+				r.required = true
+			}
+		case fd.Message() != nil && fd.ContainingOneof() != nil:
+			// The rules of the field type, such as string or int32
+			r.parseTypeRules(v.Message())
+		}
+		return true
+	})
+	return r
+}
+
+func (r *fieldRules) parseTypeRules(m protoreflect.Message) {
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch name := fd.Name(); name {
+		case "const":
+			r.constVal = &v
+		case "in", "example":
+			l := v.List()
+			for i := 0; i < l.Len(); i++ {
+				if name == "in" {
+					r.in = append(r.in, l.Get(i))
+				} else {
+					r.examples = append(r.examples, l.Get(i))
+				}
+			}
+		case "gt", "gte":
+			if n, ok := numericValue(fd, v); ok {
+				r.min, r.minExclusive = &n, name == "gt"
+			}
+		case "lt", "lte":
+			if n, ok := numericValue(fd, v); ok {
+				r.max, r.maxExclusive = &n, name == "lt"
+			}
+		case "len", "len_bytes":
+			n := v.Uint()
+			r.minLen, r.maxLen = &n, &n
+		case "min_len", "min_bytes":
+			n := v.Uint()
+			r.minLen = &n
+		case "max_len", "max_bytes":
+			n := v.Uint()
+			r.maxLen = &n
+		case "min_items", "min_pairs":
+			r.minItems = v.Uint()
+		case "items":
+			r.items = parseFieldRules(v.Message())
+		case "keys":
+			r.mapKeys = parseFieldRules(v.Message())
+		case "values":
+			r.mapValues = parseFieldRules(v.Message())
+		case "email", "hostname", "ip", "ipv4", "ipv6", "uri", "uri_ref", "uuid", "tuuid", "address", "host_and_port":
+			if fd.Kind() == protoreflect.BoolKind && v.Bool() {
+				r.format = string(name)
+			}
+		}
+		return true
+	})
+}
+
+// exampleGenerator builds example messages of a schema
+type exampleGenerator struct {
+	opts  exampleOptions
+	rand  *rand.Rand
+	types *dynamicpb.Types
+	// path holds the messages being generated, so recursive fields are left
+	// unset
+	path map[protoreflect.FullName]bool
+}
+
+func newExampleGenerator(files *protoregistry.Files, opts exampleOptions) *exampleGenerator {
+	seed := opts.Seed
+	if seed == 0 {
+		seed = 1
+	}
+	return &exampleGenerator{
+		opts:  opts,
+		rand:  rand.New(rand.NewSource(seed)),
+		types: dynamicpb.NewTypes(files),
+		path:  make(map[protoreflect.FullName]bool),
+	}
+}
+
+func (g *exampleGenerator) fieldRules(fd protoreflect.FieldDescriptor) *fieldRules {
+	if v, ok := optionExtension(fd, bufValidateField, g.types); ok {
+		return parseFieldRules(v.Message())
+	}
+	if v, ok := optionExtension(fd, pgvRules, g.types); ok {
+		return parseFieldRules(v.Message())
+	}
+	return &fieldRules{}
+}
+
+func (g *exampleGenerator) isRequired(fd protoreflect.FieldDescriptor, r *fieldRules) bool {
+	if fd.Cardinality() == protoreflect.Required || !r.allowsEmpty() {
+		return true
+	}
+	if v, ok := optionExtension(fd, googleFieldBehavior, g.types); ok {
+		l := v.List()
+		for i := 0; i < l.Len(); i++ {
+			if l.Get(i).Enum() == fieldBehaviorRequire {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (g *exampleGenerator) isOneofRequired(od protoreflect.OneofDescriptor) bool {
+	if v, ok := optionExtension(od, bufValidateOneof, g.types); ok {
+		if req := v.Message().Descriptor().Fields().ByName("required"); req != nil && v.Message().Get(req).Bool() {
+			return true
+		}
+	}
+	if v, ok := optionExtension(od, pgvRequired, g.types); ok && v.Bool() {
+		return true
+	}
+	return false
+}
+
+// message returns an example of the message. The name of the field it's for
+// and its rules are used for the value of wrapper types.
+func (g *exampleGenerator) message(md protoreflect.MessageDescriptor, name string, r *fieldRules) *dynamicpb.Message {
+	m := dynamicpb.NewMessage(md)
+	if g.wellKnown(m, name, r) {
+		return m
+	}
+
+	g.path[md.FullName()] = true
+	defer delete(g.path, md.FullName())
+
+	required := g.opts.Mode == exampleModeRequired
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		rules := g.fieldRules(fd)
+		if od := fd.ContainingOneof(); od != nil && !od.IsSynthetic() {
+			// Only one field of a oneof can be set, the first one that can
+			// be generated
+			if m.WhichOneof(od) != nil {
+				continue
+			}
+			rules.required = rules.required || g.isOneofRequired(od)
+		} else {
+			rules.required = g.isRequired(fd, rules)
+		}
+		if required && !rules.required {
+			continue
+		}
+		g.setField(m, fd, rules)
+	}
+	return m
+}
+
+func (g *exampleGenerator) setField(m *dynamicpb.Message, fd protoreflect.FieldDescriptor, r *fieldRules) {
+	name := string(fd.Name())
+	switch {
+	case fd.IsMap():
+		kr, vr := r.mapKeys, r.mapValues
+		if kr == nil {
+			kr = &fieldRules{}
+		}
+		if vr == nil {
+			vr = &fieldRules{}
+		}
+		key := g.value(fd.MapKey(), name, kr)
+		val := g.value(fd.MapValue(), name, vr)
+		if !val.IsValid() {
+			return
+		}
+		if fd.MapKey().Kind() == protoreflect.StringKind && key.String() == "" {
+			key = protoreflect.ValueOfString("key")
+		}
+		m.Mutable(fd).Map().Set(key.MapKey(), val)
+	case fd.IsList():
+		ir := r.items
+		if ir == nil {
+			ir = &fieldRules{}
+		}
+		n := r.minItems
+		if n == 0 {
+			n = 1
+		}
+		var vals []protoreflect.Value
+		for i := uint64(0); i < n; i++ {
+			v := g.value(fd, name, ir)
+			if !v.IsValid() {
+				return
+			}
+			vals = append(vals, v)
+		}
+		l := m.Mutable(fd).List()
+		for _, v := range vals {
+			l.Append(v)
+		}
+	default:
+		if v := g.value(fd, name, r); v.IsValid() {
+			m.Set(fd, v)
+		}
+	}
+}
+
+// value returns a single value of the field, or an invalid value for a
+// message that is already being generated
+func (g *exampleGenerator) value(fd protoreflect.FieldDescriptor, name string, r *fieldRules) protoreflect.Value {
+	if md := fd.Message(); md != nil {
+		if g.path[md.FullName()] {
+			return protoreflect.Value{}
+		}
+		return protoreflect.ValueOfMessage(g.message(md, name, r))
+	}
+
+	// Values given by the rules
+	var given *protoreflect.Value
+	switch {
+	case r.constVal != nil:
+		given = r.constVal
+	case len(r.examples) > 0:
+		given = &r.examples[g.rand.Intn(len(r.examples))]
+	case len(r.in) > 0:
+		given = &r.in[g.rand.Intn(len(r.in))]
+	}
+	if given != nil {
+		if fd.Kind() == protoreflect.EnumKind {
+			// Enum rules give their values as int32
+			return protoreflect.ValueOfEnum(protoreflect.EnumNumber(given.Int()))
+		}
+		return *given
+	}
+
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return protoreflect.ValueOfBool(g.opts.Fake || r.required)
+	case protoreflect.EnumKind:
+		return protoreflect.ValueOfEnum(g.enum(fd.Enum(), r))
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(g.str(name, r))
+	case protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes([]byte(g.str(name, r)))
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return protoreflect.ValueOfInt32(int32(g.number(name, r, true, math.MinInt32, math.MaxInt32)))
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return protoreflect.ValueOfInt64(int64(g.number(name, r, true, math.MinInt64, math.MaxInt64)))
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return protoreflect.ValueOfUint32(uint32(g.number(name, r, true, 0, math.MaxUint32)))
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return protoreflect.ValueOfUint64(uint64(g.number(name, r, true, 0, math.MaxUint64)))
+	case protoreflect.FloatKind:
+		return protoreflect.ValueOfFloat32(float32(g.number(name, r, false, -math.MaxFloat32, math.MaxFloat32)))
+	case protoreflect.DoubleKind:
+		return protoreflect.ValueOfFloat64(g.number(name, r, false, -math.MaxFloat64, math.MaxFloat64))
+	}
+	return protoreflect.Value{}
+}
+
+// enum returns the first value, or with fake data or a required field the
+// first one other than the zero value, which is usually UNSPECIFIED
+func (g *exampleGenerator) enum(ed protoreflect.EnumDescriptor, r *fieldRules) protoreflect.EnumNumber {
+	vals := ed.Values()
+	if g.opts.Fake || r.required {
+		for i := 0; i < vals.Len(); i++ {
+			if n := vals.Get(i).Number(); n != 0 {
+				return n
+			}
+		}
+	}
+	return vals.Get(0).Number()
+}
+
+func (g *exampleGenerator) str(name string, r *fieldRules) string {
+	var s string
+	switch {
+	case r.format != "":
+		s = formatExample(r.format)
+	case g.opts.Fake:
+		s = g.fakeString(name)
+	case r.required:
+		// A required field can't be empty, so it's given its name
+		s = name
+	}
+
+	runes := []rune(s)
+	if r.minLen != nil && uint64(len(runes)) < *r.minLen {
+		runes = append(runes, []rune(strings.Repeat("x", int(*r.minLen)-len(runes)))...)
+	}
+	if r.maxLen != nil && uint64(len(runes)) > *r.maxLen {
+		runes = runes[:*r.maxLen]
+	}
+	return string(runes)
+}
+
+// number returns a value within the bounds of the rules and the type
+func (g *exampleGenerator) number(name string, r *fieldRules, integer bool, lo, hi float64) float64 {
+	var n float64
+	switch {
+	case g.opts.Fake:
+		n = g.fakeNumber(name, integer)
+	case r.required:
+		n = 1
+	}
+
+	step := 1.0
+	if !integer {
+		step = 0.5
+	}
+	if r.min != nil {
+		min := *r.min
+		if r.minExclusive {
+			min += step
+		}
+		lo = math.Max(lo, min)
+	}
+	if r.max != nil {
+		max := *r.max
+		if r.maxExclusive {
+			max -= step
+		}
+		hi = math.Min(hi, max)
+	}
+	n = math.Max(lo, math.Min(hi, n))
+	if integer {
+		n = math.Round(n)
+	}
+	return n
+}
+
+func formatExample(format string) string {
+	switch format {
+	case "email":
+		return "jane.doe@example.com"
+	case "hostname":
+		return "api.example.com"
+	case "ip", "ipv4":
+		return "192.0.2.1"
+	case "ipv6":
+		return "2001:db8::1"
+	case "uri", "uri_ref":
+		return "https://example.com/path"
+	case "uuid":
+		return "8d3c2f4e-5b6a-4c1d-9e8f-7a6b5c4d3e2f"
+	case "tuuid":
+		return "8d3c2f4e5b6a4c1d9e8f7a6b5c4d3e2f"
+	case "address", "host_and_port":
+		return "api.example.com:443"
+	}
+	return ""
+}
+
+// nameWords splits a field name into its lower case words, along with each
+// pair of adjacent words joined, so first_name also matches firstname
+func nameWords(name string) map[string]bool {
+	var words []string
+	var cur []rune
+	flush := func() {
+		if len(cur) > 0 {
+			words = append(words, strings.ToLower(string(cur)))
+			cur = nil
+		}
+	}
+	rs := []rune(name)
+	for i, r := range rs {
+		switch {
+		case r == '_' || r == '-' || r == '.':
+			flush()
+			continue
+		case unicode.IsUpper(r) && i > 0 && unicode.IsLower(rs[i-1]):
+			flush()
+		}
+		cur = append(cur, r)
+	}
+	flush()
+
+	set := make(map[string]bool)
+	for i, w := range words {
+		set[w] = true
+		if i > 0 {
+			set[words[i-1]+w] = true
+		}
+	}
+	return set
+}
+
+// fakeStrings are realistic values for field names with the words
+var fakeStrings = []struct {
+	words  []string
+	values []string
+}{
+	{[]string{"email", "mail"}, []string{"jane.doe@example.com", "john.smith@example.org", "alex.kim@example.net"}},
+	{[]string{"url", "uri", "link", "website", "href", "endpoint"}, []string{"https://example.com", "https://example.org/docs"}},
+	{[]string{"phone", "mobile", "tel", "telephone"}, []string{"+1-202-555-0142", "+44 20 7946 0958"}},
+	{[]string{"firstname", "givenname"}, []string{"Jane", "John", "Alex"}},
+	{[]string{"lastname", "surname", "familyname"}, []string{"Doe", "Smith", "Kim"}},
+	{[]string{"username", "login", "handle", "nickname"}, []string{"jdoe", "jsmith", "akim"}},
+	{[]string{"street", "address", "line1"}, []string{"1600 Amphitheatre Parkway", "221B Baker Street"}},
+	{[]string{"city", "town"}, []string{"Springfield", "London", "Sydney"}},
+	{[]string{"state", "province", "region"}, []string{"CA", "NY", "WA"}},
+	{[]string{"country"}, []string{"US", "GB", "AU"}},
+	{[]string{"zip", "zipcode", "postal", "postcode"}, []string{"94043", "10001"}},
+	{[]string{"currency"}, []string{"USD", "EUR", "GBP"}},
+	{[]string{"language", "lang", "locale"}, []string{"en-US", "en-GB", "fr-FR"}},
+	{[]string{"timezone", "tz"}, []string{"America/Los_Angeles", "Europe/London"}},
+	{[]string{"ip", "ipv4"}, []string{"192.0.2.1", "198.51.100.7"}},
+	{[]string{"host", "hostname", "domain"}, []string{"api.example.com", "example.org"}},
+	{[]string{"uuid", "guid", "id"}, nil},
+	{[]string{"token", "key", "secret", "hash", "checksum"}, nil},
+	{[]string{"password", "passphrase"}, []string{"correct-horse-battery-staple"}},
+	{[]string{"date", "day", "birthday"}, []string{"2024-05-17", "2023-11-02"}},
+	{[]string{"time", "timestamp"}, []string{"2024-05-17T09:30:00Z"}},
+	{[]string{"color", "colour"}, []string{"#1e90ff", "#ff6347"}},
+	{[]string{"sku", "code"}, []string{"SKU-12345", "AB-987"}},
+	{[]string{"version"}, []string{"1.0.0", "2.3.1"}},
+	{[]string{"path", "file", "filename"}, []string{"/tmp/example.txt", "docs/readme.md"}},
+	{[]string{"mime", "contenttype", "mediatype"}, []string{"application/json", "image/png"}},
+	{[]string{"title", "subject", "headline"}, []string{"Quarterly report", "Welcome aboard"}},
+	{[]string{"description", "summary", "comment", "note", "notes", "message", "body", "text", "content", "bio"}, []string{"Lorem ipsum dolor sit amet.", "The quick brown fox jumps over the lazy dog."}},
+	{[]string{"company", "organization", "org"}, []string{"Acme Corp", "Globex"}},
+	{[]string{"name", "displayname", "fullname"}, []string{"Jane Doe", "John Smith", "Alex Kim"}},
+}
+
+func (g *exampleGenerator) fakeString(name string) string {
+	words := nameWords(name)
+	for _, f := range fakeStrings {
+		for _, w := range f.words {
+			if !words[w] {
+				continue
+			}
+			switch {
+			case f.words[0] == "uuid":
+				return g.fakeUUID()
+			case f.words[0] == "token":
+				return g.fakeHex(32)
+			}
+			return f.values[g.rand.Intn(len(f.values))]
+		}
+	}
+	return "example " + strings.Join(strings.FieldsFunc(name, func(r rune) bool { return r == '_' }), " ")
+}
+
+func (g *exampleGenerator) fakeHex(n int) string {
+	const digits = "0123456789abcdef"
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = digits[g.rand.Intn(len(digits))]
+	}
+	return string(b)
+}
+
+func (g *exampleGenerator) fakeUUID() string {
+	h := g.fakeHex(32)
+	// Version 4, variant 10
+	return h[:8] + "-" + h[8:12] + "-4" + h[13:16] + "-a" + h[17:20] + "-" + h[20:]
+}
+
+// fakeNumbers are realistic values for field names with the words, given
+// as a range
+var fakeNumbers = []struct {
+	words  []string
+	lo, hi float64
+}{
+	{[]string{"lat", "latitude"}, 37.3, 37.5},
+	{[]string{"lng", "lon", "long", "longitude"}, -122.2, -122.0},
+	{[]string{"age"}, 18, 80},
+	{[]string{"year"}, 2000, 2025},
+	{[]string{"month"}, 1, 12},
+	{[]string{"day"}, 1, 28},
+	{[]string{"hour", "hours"}, 0, 23},
+	{[]string{"minute", "minutes", "second", "seconds"}, 0, 59},
+	{[]string{"port"}, 1024, 9000},
+	{[]string{"percent", "percentage"}, 0, 100},
+	{[]string{"ratio", "rate", "probability"}, 0, 1},
+	{[]string{"rating", "stars"}, 1, 5},
+	{[]string{"price", "amount", "cost", "total", "balance", "fee"}, 1, 500},
+	{[]string{"count", "quantity", "qty", "num", "number"}, 1, 10},
+	{[]string{"limit", "size", "pagesize"}, 10, 100},
+	{[]string{"page", "offset"}, 1, 10},
+	{[]string{"timeout", "ttl", "duration", "ms", "millis"}, 100, 5000},
+	{[]string{"weight", "kg"}, 50, 100},
+	{[]string{"height", "width", "length", "cm"}, 10, 200},
+	{[]string{"priority", "level", "version"}, 1, 5},
+	{[]string{"id"}, 1000, 99999},
+}
+
+func (g *exampleGenerator) fakeNumber(name string, integer bool) float64 {
+	lo, hi := 1.0, 100.0
+	words := nameWords(name)
+found:
+	for _, f := range fakeNumbers {
+		for _, w := range f.words {
+			if words[w] {
+				lo, hi = f.lo, f.hi
+				break found
+			}
+		}
+	}
+	n := lo + g.rand.Float64()*(hi-lo)
+	if integer {
+		return math.Round(n)
+	}
+	return math.Round(n*100) / 100
+}
+
+// wellKnown sets the example of a well known type, whose JSON form isn't
+// that of its fields, returning false for other messages
+func (g *exampleGenerator) wellKnown(m *dynamicpb.Message, name string, r *fieldRules) bool {
+	md := m.Descriptor()
+	if md.ParentFile().Package() != "google.protobuf" {
+		return false
+	}
+	fields := md.Fields()
+	switch md.Name() {
+	case "Timestamp":
+		if g.opts.Fake {
+			// A time in 2024
+			m.Set(fields.ByName("seconds"), protoreflect.ValueOfInt64(1704067200+g.rand.Int63n(366*86400)))
+		}
+	case "Duration":
+		if g.opts.Fake {
+			m.Set(fields.ByName("seconds"), protoreflect.ValueOfInt64(1+g.rand.Int63n(300)))
+		}
+	case "Struct", "ListValue", "Any", "FieldMask", "Empty":
+		// Left empty, as their content isn't described by the schema
+	case "Value":
+		m.Set(fields.ByName("string_value"), protoreflect.ValueOfString(g.str(name, r)))
+	case "DoubleValue", "FloatValue", "Int64Value", "UInt64Value", "Int32Value", "UInt32Value",
+		"BoolValue", "StringValue", "BytesValue":
+		// Wrappers have the rules of the field they're used for
+		fd := fields.ByName("value")
+		if v := g.value(fd, name, r); v.IsValid() {
+			m.Set(fd, v)
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// generateExample returns an example of the message as JSON
+func generateExample(files *protoregistry.Files, md protoreflect.MessageDescriptor, opts exampleOptions) (string, error) {
+	switch opts.Mode {
+	case "":
+		opts.Mode = exampleModeAll
+	case exampleModeAll, exampleModeRequired:
+	default:
+		return "", fmt.Errorf("unknown example mode %q", opts.Mode)
+	}
+	g := newExampleGenerator(files, opts)
+	m := g.message(md, string(md.Name()), &fieldRules{})
+	b, err := protojson.MarshalOptions{
+		Multiline:     true,
+		Indent:        "  ",
+		UseProtoNames: true,
+		// Zero values are shown so every field can be filled in
+		EmitUnpopulated: opts.Mode == exampleModeAll,
+	}.Marshal(m)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal example: %v", err)
+	}
+	return string(b), nil
+}
+
+// exampleMessage returns the input of the method with the path, such as
+// /pkg.Service/Method, or else the message with the full name
+func (c *connection) exampleMessage(name string) (protoreflect.MessageDescriptor, error) {
+	if strings.HasPrefix(name, "/") {
+		md, err := c.methodDesc(name)
+		if err != nil {
+			return nil, err
+		}
+		return md.Input(), nil
+	}
+	files := c.files()
+	if files == nil {
+		return nil, errors.New("no proto files loaded")
+	}
+	d, err := files.FindDescriptorByName(protoreflect.FullName(name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find message %q: %v", name, err)
+	}
+	md, ok := d.(protoreflect.MessageDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a message", name)
+	}
+	return md, nil
+}
+
+// GenerateExample returns an example of the request of the method with the
+// path, such as /pkg.Service/Method, or of the message with the full name,
+// as JSON populated according to the options
+func (a *api) GenerateExample(name string, rawOpts interface{}) (example string, rerr error) {
+	defer func() {
+		if rerr != nil {
+			const errTitle = "Failed to generate example"
+			runtime.LogError(a.ctx, rerr.Error())
+			a.emitError(errTitle, rerr.Error())
+		}
+	}()
+
+	var opts exampleOptions
+	if err := mapstructure.Decode(rawOpts, &opts); err != nil {
+		return "", fmt.Errorf("failed to decode example options: %v", err)
+	}
+	conn := a.current()
+	if conn == nil {
+		return "", errors.New("no proto files loaded")
+	}
+	md, err := conn.exampleMessage(name)
+	if err != nil {
+		return "", err
+	}
+	return generateExample(conn.files(), md, opts)
+}
+
+// SelectMethodExample selects the method with an example of its request,
+// generated with the options, as the initial state in the request format of
+// the workspace
+func (a *api) SelectMethodExample(fullname string, rawOpts interface{}, metadata interface{}) error {
+	example, err := a.GenerateExample(fullname, rawOpts)
+	if err != nil {
+		return err
+	}
+	if conn := a.current(); conn != nil {
+		if md, err := conn.methodDesc(fullname); err == nil {
+			if example, err = conn.opts.requestFormat().fromJSON(md.Input(), example); err != nil {
+				return err
+			}
+		}
+	}
+	return a.SelectMethod(fullname, example, metadata)
+}
@@ -0,0 +1,258 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// defaultFanOutTimeout limits the call to a workspace without a timeout, so
+// a stream that never ends can't hold up the others
+const defaultFanOutTimeout = 30 * time.Second
+
+// fanOutOptions set the workspace the others are compared to, which is the
+// first workspace unless set, and the timeout in seconds of the call to each
+// workspace without one. They are decoded along with the diff options.
+type fanOutOptions struct {
+	Baseline string  `json:"baseline"`
+	Timeout  float64 `json:"timeout"`
+}
+
+// fanOutEntry is the outcome of the call to a single workspace
+type fanOutEntry struct {
+	WorkspaceID   string              `json:"workspace_id"`
+	Addr          string              `json:"addr"`
+	CallID        string              `json:"call_id"`
+	Status        string              `json:"status"`
+	StatusCode    int32               `json:"status_code"`
+	StatusMessage string              `json:"status_message"`
+	Duration      float64             `json:"duration_ms"`
+	Responses     []string            `json:"responses"`
+	Trailer       map[string][]string `json:"trailer"`
+	Error         string              `json:"error"`
+	Equal         bool                `json:"equal"`
+	Diffs         []responseDiff      `json:"diffs"`
+
+	entry  historyEntry
+	output protoreflect.MessageDescriptor
+}
+
+type fanOutResult struct {
+	Method   string        `json:"method"`
+	Baseline string        `json:"baseline"`
+	Equal    bool          `json:"equal"`
+	Results  []fanOutEntry `json:"results"`
+}
+
+// openWorkspace returns the connection of the workspace, connecting it in the
+// background if needed without making it the current workspace
+func (a *api) openWorkspace(id string) (*connection, error) {
+	opts, err := a.workspaceOptions(id)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Addr == "" {
+		return nil, fmt.Errorf("workspace %q has no address", id)
+	}
+
+	for {
+		conn, created := a.conns.claim(id, func() *connection {
+			return newConnection(id, *opts, a.newConnTimeline(id))
+		})
+		if created {
+			return a.dialWorkspace(conn)
+		}
+		// A workspace that is still connecting or loading its proto files is
+		// waited for rather than replaced, which would close it
+		<-conn.loaded
+		if conn.files() != nil {
+			return conn, nil
+		}
+		if conn.state() != connectivity.Shutdown {
+			return nil, fmt.Errorf("workspace %q has no proto files loaded", id)
+		}
+	}
+}
+
+// dialWorkspace connects the new connection of a workspace and loads its
+// proto files
+func (a *api) dialWorkspace(conn *connection) (*connection, error) {
+	opts := conn.opts
+
+	var ctx context.Context
+	ctx, conn.cancelMonitoring = context.WithCancel(context.Background())
+	if !isHTTPTransport(opts.Transport) {
+		go a.monitorStateChanges(ctx, conn)
+	}
+
+	if err := conn.client.connect(opts, statsHandler{a, conn.client.timeline}); err != nil {
+		a.conns.remove(conn.id, conn)
+		return nil, fmt.Errorf("failed to connect to server: %v", err)
+	}
+
+	hds, _ := a.GetReflectMetadata(opts.Addr)
+	if err := a.loadProtoFiles(conn, hds, true); err != nil {
+		return nil, err
+	}
+	return conn, nil
+}
+
+// fanOutCall sends the request to a single workspace and waits for the call
+// to complete. A client stream is sent the request as its only message.
+func (a *api) fanOutCall(id, method, body string, format requestFormat, rawHeaders interface{}, timeout time.Duration) fanOutEntry {
+	e := fanOutEntry{WorkspaceID: id}
+
+	conn, err := a.openWorkspace(id)
+	if err != nil {
+		e.Error = err.Error()
+		return e
+	}
+	e.Addr = conn.opts.Addr
+
+	c, err := a.openCall(id, method, rawHeaders)
+	if err != nil {
+		e.Error = err.Error()
+		return e
+	}
+	c.limit(timeout)
+	e.CallID = c.id
+	e.output = c.desc.Output()
+
+	req, err := format.decode(c.desc.Input(), []byte(body))
+	if err != nil {
+		c.cancel()
+		e.Error = fmt.Sprintf("failed to unmarshal request: %v", err)
+		return e
+	}
+	if err := a.validateRequest(c.conn, method, req); err != nil {
+		c.cancel()
+		e.Error = err.Error()
+		return e
+	}
+
+	var first proto.Message = req
+	if c.desc.IsStreamingClient() {
+		c.reqs <- req
+		c.closeSend()
+		first = nil
+	}
+	err = a.runCall(c, first)
+
+	e.entry = recorderFromContext(c.ctx).snapshot()
+	if e.entry.Status == "" && err != nil {
+		// The call failed before it was sent, otherwise its status is
+		// compared like any response
+		e.Error = err.Error()
+	}
+	e.Status = e.entry.Status
+	e.StatusCode = e.entry.StatusCode
+	e.StatusMessage = e.entry.StatusMessage
+	e.Duration = e.entry.Duration
+	e.Trailer = e.entry.Trailer
+	for _, m := range e.entry.messages(false) {
+		e.Responses = append(e.Responses, m.JSON)
+	}
+	return e
+}
+
+// FanOut sends the method, body and metadata to each of the workspaces at
+// once. The responses, status and latency of each workspace are compared to
+// those of the baseline workspace.
+func (a *api) FanOut(workspaceIDs []string, method, body string, rawHeaders interface{}, rawOpts interface{}) (res *fanOutResult, rerr error) {
+	defer func() {
+		if rerr != nil {
+			const errTitle = "Unable to fan out request"
+			runtime.LogError(a.ctx, rerr.Error())
+			a.emitError(errTitle, rerr.Error())
+		}
+	}()
+
+	var opts fanOutOptions
+	if err := mapstructure.Decode(rawOpts, &opts); err != nil {
+		return nil, fmt.Errorf("failed to decode fan out options: %v", err)
+	}
+	var dopts diffOptions
+	if err := mapstructure.Decode(rawOpts, &dopts); err != nil {
+		return nil, fmt.Errorf("failed to decode diff options: %v", err)
+	}
+	if len(workspaceIDs) == 0 {
+		return nil, errors.New("no workspaces selected")
+	}
+	if opts.Timeout < 0 {
+		return nil, errors.New("fan out timeout must not be negative")
+	}
+	timeout := defaultFanOutTimeout
+	if opts.Timeout > 0 {
+		timeout = seconds(opts.Timeout)
+	}
+	if opts.Baseline == "" {
+		opts.Baseline = workspaceIDs[0]
+	}
+	base := -1
+	for i, id := range workspaceIDs {
+		if id == opts.Baseline {
+			base = i
+		}
+	}
+	if base < 0 {
+		return nil, fmt.Errorf("baseline workspace %q was not selected", opts.Baseline)
+	}
+
+	res = &fanOutResult{
+		Method:   method,
+		Baseline: opts.Baseline,
+		Equal:    true,
+		Results:  make([]fanOutEntry, len(workspaceIDs)),
+	}
+	// The body is in the format of the workspace it was written in
+	var format requestFormat
+	if conn := a.current(); conn != nil {
+		format = conn.opts.requestFormat()
+	}
+	var wg sync.WaitGroup
+	for i, id := range workspaceIDs {
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+			res.Results[i] = a.fanOutCall(id, method, body, format, rawHeaders, timeout)
+		}(i, id)
+	}
+	wg.Wait()
+
+	want := &res.Results[base]
+	want.Equal = want.Error == ""
+	for i := range res.Results {
+		got := &res.Results[i]
+		if i == base {
+			continue
+		}
+		if got.Error != "" || want.Error != "" {
+			got.Equal = false
+			res.Equal = false
+			continue
+		}
+		diffs, equal, err := diffResponses(want.output, got.output, want.entry.messages(false), got.entry.messages(false), dopts)
+		if err != nil {
+			got.Error = err.Error()
+		}
+		got.Diffs = diffs
+		got.Equal = err == nil && equal && got.Status == want.Status
+		if !got.Equal {
+			res.Equal = false
+		}
+	}
+	if want.Error != "" {
+		res.Equal = false
+	}
+
+	runtime.EventsEmit(a.ctx, eventFanOutEnded, res)
+	return res, nil
+}
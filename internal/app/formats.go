@@ -0,0 +1,329 @@
+package app
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"gopkg.in/yaml.v3"
+)
+
+// Formats of request bodies. Bodies are strings in the editor, so the binary
+// format is written in base64 or hex rather than as raw bytes.
+const (
+	formatJSON   = "json"
+	formatJSONC  = "jsonc" // JSON with comments and trailing commas
+	formatYAML   = "yaml"
+	formatText   = "text"   // the protobuf text format
+	formatBase64 = "base64" // the binary format in base64
+	formatHex    = "hex"    // the binary format in hex
+)
+
+// requestFormat is how the request bodies of a workspace are written
+type requestFormat struct {
+	format string
+	// strict rejects unknown fields rather than dropping them
+	strict bool
+}
+
+func (o options) requestFormat() requestFormat {
+	return requestFormat{format: o.InputFormat, strict: o.StrictFields}
+}
+
+// isJSON reports if bodies are plain JSON, which is the default
+func (f requestFormat) isJSON() bool {
+	return f.format == "" || f.format == formatJSON
+}
+
+// decode returns the request of the body as a message of the descriptor
+func (f requestFormat) decode(md protoreflect.MessageDescriptor, body []byte) (*dynamicpb.Message, error) {
+	req := dynamicpb.NewMessage(md)
+	jsonOpts := protojson.UnmarshalOptions{DiscardUnknown: !f.strict}
+
+	switch f.format {
+	case "", formatJSON:
+		if err := jsonOpts.Unmarshal(body, req); err != nil {
+			return nil, err
+		}
+	case formatJSONC:
+		if err := jsonOpts.Unmarshal(stripJSONComments(body), req); err != nil {
+			return nil, err
+		}
+	case formatYAML:
+		b, err := yamlToJSON(body)
+		if err != nil {
+			return nil, err
+		}
+		if err := jsonOpts.Unmarshal(b, req); err != nil {
+			return nil, err
+		}
+	case formatText:
+		if err := (prototext.UnmarshalOptions{DiscardUnknown: !f.strict}).Unmarshal(body, req); err != nil {
+			return nil, err
+		}
+	case formatBase64, formatHex:
+		var err error
+		if f.format == formatBase64 {
+			body, err = decodeBase64(body)
+		} else {
+			body, err = decodeHex(body)
+		}
+		if err != nil {
+			return nil, err
+		}
+		// Unknown fields are kept, so captured requests are replayed as
+		// they were
+		if err := proto.Unmarshal(unframeRequest(body), req); err != nil {
+			return nil, err
+		}
+		if f.strict {
+			if err := checkUnknown(req, ""); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unknown request format %q", f.format)
+	}
+	return req, nil
+}
+
+// encode returns the message in the format, as a body for the editor
+func (f requestFormat) encode(m proto.Message) (string, error) {
+	switch f.format {
+	case "", formatJSON, formatJSONC:
+		b, err := protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(m)
+		return string(b), err
+	case formatYAML:
+		b, err := protojson.Marshal(m)
+		if err != nil {
+			return "", err
+		}
+		return jsonToYAML(b)
+	case formatText:
+		b, err := prototext.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(m)
+		return string(b), err
+	case formatBase64:
+		b, err := proto.Marshal(m)
+		return base64.StdEncoding.EncodeToString(b), err
+	case formatHex:
+		b, err := proto.Marshal(m)
+		return hex.EncodeToString(b), err
+	}
+	return "", fmt.Errorf("unknown request format %q", f.format)
+}
+
+// fromJSON converts a JSON body to the format. JSON and YAML keep the
+// fields set to zero values.
+func (f requestFormat) fromJSON(md protoreflect.MessageDescriptor, data string) (string, error) {
+	switch f.format {
+	case "", formatJSON, formatJSONC:
+		return data, nil
+	case formatYAML:
+		return jsonToYAML([]byte(data))
+	}
+	req := dynamicpb.NewMessage(md)
+	if err := protojson.Unmarshal([]byte(data), req); err != nil {
+		return "", err
+	}
+	return f.encode(req)
+}
+
+// requestJSON returns the body as JSON, unchanged if it already is
+func (f requestFormat) requestJSON(md protoreflect.MessageDescriptor, body []byte) ([]byte, error) {
+	if f.isJSON() {
+		return body, nil
+	}
+	req, err := f.decode(md, body)
+	if err != nil {
+		return nil, err
+	}
+	return protojson.Marshal(req)
+}
+
+// stripJSONComments removes the // and /* */ comments of the JSON, and the
+// commas trailing the last item of objects and arrays
+func stripJSONComments(b []byte) []byte {
+	var out bytes.Buffer
+	// comma is the offset in out of a comma that may be trailing
+	comma := -1
+	for i := 0; i < len(b); i++ {
+		c := b[i]
+		switch {
+		case c == '"':
+			// Copy the string, with its escapes
+			j := i + 1
+			for j < len(b) && b[j] != '"' {
+				if b[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j >= len(b) {
+				j = len(b) - 1
+			}
+			out.Write(b[i : j+1])
+			i = j
+			comma = -1
+			continue
+		case c == '/' && i+1 < len(b) && b[i+1] == '/':
+			for i < len(b) && b[i] != '\n' {
+				i++
+			}
+			out.WriteByte('\n')
+			continue
+		case c == '/' && i+1 < len(b) && b[i+1] == '*':
+			end := bytes.Index(b[i+2:], []byte("*/"))
+			if end < 0 {
+				i = len(b)
+			} else {
+				i += end + 3
+			}
+			out.WriteByte(' ')
+			continue
+		case c == ',':
+			comma = out.Len()
+		case c == '}' || c == ']':
+			if comma >= 0 {
+				out.Bytes()[comma] = ' '
+			}
+			comma = -1
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+		default:
+			comma = -1
+		}
+		out.WriteByte(c)
+	}
+	return out.Bytes()
+}
+
+// yamlToJSON converts a YAML document to JSON
+func yamlToJSON(b []byte) ([]byte, error) {
+	var v interface{}
+	if err := yaml.Unmarshal(b, &v); err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(jsonValue(v))
+}
+
+// jsonValue converts the maps YAML decodes keys that aren't strings into,
+// which JSON can't encode
+func jsonValue(v interface{}) interface{} {
+	switch x := v.(type) {
+	case map[string]interface{}:
+		for k, e := range x {
+			x[k] = jsonValue(e)
+		}
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(x))
+		for k, e := range x {
+			m[fmt.Sprint(k)] = jsonValue(e)
+		}
+		return m
+	case []interface{}:
+		for i, e := range x {
+			x[i] = jsonValue(e)
+		}
+	}
+	return v
+}
+
+// jsonToYAML converts JSON to a YAML document in the block style, keeping
+// the order of the fields
+func jsonToYAML(b []byte) (string, error) {
+	var n yaml.Node
+	if err := yaml.Unmarshal(b, &n); err != nil {
+		return "", err
+	}
+	var blockStyle func(n *yaml.Node)
+	blockStyle = func(n *yaml.Node) {
+		n.Style = 0
+		for _, c := range n.Content {
+			blockStyle(c)
+		}
+	}
+	blockStyle(&n)
+	var out bytes.Buffer
+	enc := yaml.NewEncoder(&out)
+	enc.SetIndent(2)
+	if err := enc.Encode(&n); err != nil {
+		return "", err
+	}
+	return out.String(), enc.Close()
+}
+
+// decodeBase64 decodes standard or URL base64, with or without padding and
+// ignoring whitespace
+func decodeBase64(b []byte) ([]byte, error) {
+	s := strings.Join(strings.Fields(string(b)), "")
+	enc := base64.RawStdEncoding
+	if strings.ContainsAny(s, "-_") {
+		enc = base64.RawURLEncoding
+	}
+	data, err := enc.DecodeString(strings.TrimRight(s, "="))
+	if err != nil {
+		return nil, fmt.Errorf("invalid base64: %v", err)
+	}
+	return data, nil
+}
+
+// decodeHex decodes hex, ignoring whitespace
+func decodeHex(b []byte) ([]byte, error) {
+	data, err := hex.DecodeString(strings.Join(strings.Fields(string(b)), ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex: %v", err)
+	}
+	return data, nil
+}
+
+// unframeRequest strips the gRPC envelope of a captured request. Messages
+// can't start with a zero byte, which isn't a valid tag, so one that does
+// with the length of the rest is a frame.
+func unframeRequest(b []byte) []byte {
+	if len(b) >= envelopeHeaderLen && b[0] == 0 && int(binary.BigEndian.Uint32(b[1:envelopeHeaderLen])) == len(b)-envelopeHeaderLen {
+		return b[envelopeHeaderLen:]
+	}
+	return b
+}
+
+// checkUnknown returns an error for the first unknown field of the message
+// or the messages it contains
+func checkUnknown(m protoreflect.Message, path string) error {
+	if len(m.GetUnknown()) > 0 {
+		if path == "" {
+			return fmt.Errorf("unknown field in %s", m.Descriptor().FullName())
+		}
+		return fmt.Errorf("unknown field in %s (%s)", path, m.Descriptor().FullName())
+	}
+	var err error
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		p := joinPath(path, fd)
+		switch {
+		case fd.IsMap() && fd.MapValue().Message() != nil:
+			v.Map().Range(func(k protoreflect.MapKey, mv protoreflect.Value) bool {
+				err = checkUnknown(mv.Message(), p+"["+k.String()+"]")
+				return err == nil
+			})
+		case fd.IsList() && fd.Message() != nil:
+			l := v.List()
+			for i := 0; i < l.Len() && err == nil; i++ {
+				err = checkUnknown(l.Get(i).Message(), fmt.Sprintf("%s[%d]", p, i))
+			}
+		case !fd.IsMap() && !fd.IsList() && fd.Message() != nil:
+			err = checkUnknown(v.Message(), p)
+		}
+		return err == nil
+	})
+	return err
+}
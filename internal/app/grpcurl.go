@@ -3,6 +3,10 @@ package app
 import (
 	"errors"
 	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
 	"strings"
 
 	"github.com/google/shlex"
@@ -24,13 +28,69 @@ type grpcurlArguments struct {
 	Method   string  `json:"method"`
 	Metadata headers `json:"metadata"`
 	Data     string  `json:"data"`
+	// Format is the format of Data: json or text
+	Format string `json:"format"`
+	// AllowUnknownFields drops unknown fields of Data rather than failing
+	AllowUnknownFields bool `json:"allow_unknown_fields"`
+	// MsgTemplate requests an example of the method's request when there is
+	// no data, as grpcurl's -msg-template describes the request type
+	MsgTemplate bool `json:"msg_template"`
+
+	ReflectMetadata headers `json:"reflect_metadata"`
+
+	Plaintext  bool   `json:"plaintext"`
+	Insecure   bool   `json:"insecure"`
+	Unix       bool   `json:"unix"`
+	CACert     string `json:"cacert"`
+	Cert       string `json:"cert"`
+	Key        string `json:"key"`
+	ServerName string `json:"servername"`
+	Authority  string `json:"authority"`
+
+	Protos      []string `json:"protos"`
+	ImportPaths []string `json:"import_paths"`
+	Protosets   []string `json:"protosets"`
+
+	// Durations are in seconds, as with grpcurl
+	MaxTime        float64 `json:"max_time"`
+	ConnectTimeout float64 `json:"connect_timeout"`
+	KeepaliveTime  float64 `json:"keepalive_time"`
+}
+
+// parseHeaderLine splits a "name: value" header, as given to -H
+func parseHeaderLine(s string) (header, error) {
+	key, val, ok := strings.Cut(s, ":")
+	key = strings.TrimSpace(key)
+	if !ok || key == "" {
+		return header{}, fmt.Errorf("invalid header %q: must be name: value", s)
+	}
+	return header{Key: strings.ToLower(key), Val: strings.TrimSpace(val)}, nil
+}
+
+func parseGrpcurlHeaders(values ...multiString) (headers, error) {
+	var hs headers
+	for _, vs := range values {
+		for _, v := range vs {
+			h, err := parseHeaderLine(v)
+			if err != nil {
+				return nil, err
+			}
+			hs = append(hs, h)
+		}
+	}
+	return hs, nil
 }
 
 func parseGrpcurlCommand(command string) (*grpcurlArguments, error) {
-	args, _ := shlex.Split(command)
-	for index, arg := range args {
-		if strings.TrimSpace(arg) == "" {
-			args = append(args[:index], args[index+1:]...)
+	split, err := shlex.Split(command)
+	if err != nil {
+		return nil, err
+	}
+	var args []string
+	for _, arg := range split {
+		// Line continuations are left as empty arguments
+		if strings.TrimSpace(arg) != "" {
+			args = append(args, arg)
 		}
 	}
 
@@ -42,41 +102,40 @@ func parseGrpcurlCommand(command string) (*grpcurlArguments, error) {
 		return nil, errors.New("invalid grpcurl command: must start with 'grpcurl'")
 	}
 
-	flags := flag.NewFlagSet(args[0], flag.ExitOnError)
+	flags := flag.NewFlagSet(args[0], flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
 	// ignore flags
 	_ = flags.Bool("help", false, "")
 	_ = flags.Bool("version", false, "")
-	_ = flags.Bool("plaintext", false, "")
-	_ = flags.Bool("insecure", false, "")
-	_ = flags.String("cacert", "", "")
-	_ = flags.String("cert", "", "")
-	_ = flags.String("key", "", "")
 	_ = flags.Bool("expand-headers", false, "")
-	_ = flags.String("authority", "", "")
 	_ = flags.String("user-agent", "", "")
-	_ = flags.Bool("allow-unknown-fields", false, "")
-	_ = flags.Float64("connect-timeout", 0, "")
 	_ = flags.Bool("format-error", false, "")
-	_ = flags.Float64("keepalive-time", 0, "")
-	_ = flags.Float64("max-time", 0, "")
 	_ = flags.Int("max-msg-sz", 0, "")
 	_ = flags.Bool("emit-defaults", false, "")
 	_ = flags.String("protoset-out", "", "")
-	_ = flags.Bool("msg-template", false, "")
 	_ = flags.Bool("v", false, "")
 	_ = flags.Bool("vv", false, "")
-	_ = flags.String("servername", "", "")
 	_ = flags.Bool("use-reflection", false, "")
 
-	var data, format string
-	flags.StringVar(&data,"d", "", "")
-	flags.StringVar(&format, "format", "json", "")
+	var ga grpcurlArguments
+	flags.BoolVar(&ga.Plaintext, "plaintext", false, "")
+	flags.BoolVar(&ga.Insecure, "insecure", false, "")
+	flags.BoolVar(&ga.Unix, "unix", false, "")
+	flags.StringVar(&ga.CACert, "cacert", "", "")
+	flags.StringVar(&ga.Cert, "cert", "", "")
+	flags.StringVar(&ga.Key, "key", "", "")
+	flags.StringVar(&ga.ServerName, "servername", "", "")
+	flags.StringVar(&ga.Authority, "authority", "", "")
+	flags.Float64Var(&ga.MaxTime, "max-time", 0, "")
+	flags.Float64Var(&ga.ConnectTimeout, "connect-timeout", 0, "")
+	flags.Float64Var(&ga.KeepaliveTime, "keepalive-time", 0, "")
 
-	if format != "" && format != "json" {
-		return nil, errors.New("data format must be json")
-	}
+	flags.StringVar(&ga.Data, "d", "", "")
+	flags.BoolVar(&ga.MsgTemplate, "msg-template", false, "")
+	flags.StringVar(&ga.Format, "format", formatJSON, "")
+	flags.BoolVar(&ga.AllowUnknownFields, "allow-unknown-fields", false, "")
 
-	var protoset, protoFiles, importPaths, addlHeaders, rpcHeaders, reflHeaders  multiString
+	var protoset, protoFiles, importPaths, addlHeaders, rpcHeaders, reflHeaders multiString
 	flags.Var(&addlHeaders, "H", "")
 	flags.Var(&rpcHeaders, "rpc-header", "")
 	flags.Var(&reflHeaders, "reflect-header", "")
@@ -84,34 +143,194 @@ func parseGrpcurlCommand(command string) (*grpcurlArguments, error) {
 	flags.Var(&protoFiles, "proto", "")
 	flags.Var(&importPaths, "import-path", "")
 
-	err := flags.Parse(args[1:])
-	if err != nil {
+	if err := flags.Parse(args[1:]); err != nil {
 		return nil, err
 	}
 
+	if ga.Format != "" && ga.Format != formatJSON && ga.Format != formatText {
+		return nil, fmt.Errorf("unsupported data format %q: must be json or text", ga.Format)
+	}
+	if ga.Data == "@" {
+		return nil, errors.New("reading the request data from stdin is not supported")
+	}
+	if ga.Plaintext && ga.Insecure {
+		return nil, errors.New("-plaintext and -insecure can't be used together")
+	}
+	if ga.Plaintext && (ga.CACert != "" || ga.Cert != "" || ga.Key != "" || ga.ServerName != "") {
+		return nil, errors.New("-plaintext can't be used with TLS flags")
+	}
+	if (ga.Cert == "") != (ga.Key == "") {
+		return nil, errors.New("-cert and -key must be used together")
+	}
+	if len(protoset) > 0 && len(protoFiles) > 0 {
+		return nil, errors.New("use either -protoset or -proto files, but not both")
+	}
+
 	grpcurlArgs := flags.Args()
 	if len(grpcurlArgs) != 2 {
-		return nil, errors.New("invalid grpcurl arguments")
+		return nil, errors.New("invalid grpcurl arguments: expected an address and a method")
+	}
+	ga.Target = grpcurlArgs[0]
+	ga.Method = grpcurlArgs[1]
+	// grpcurl also accepts the method separated from the service by a dot
+	if !strings.Contains(ga.Method, "/") {
+		i := strings.LastIndex(ga.Method, ".")
+		if i < 0 {
+			return nil, fmt.Errorf("invalid method %q", ga.Method)
+		}
+		ga.Method = ga.Method[:i] + "/" + ga.Method[i+1:]
 	}
 
-	var metadata headers
+	// -H headers are sent with both the call and the reflection requests
+	if ga.Metadata, err = parseGrpcurlHeaders(addlHeaders, rpcHeaders); err != nil {
+		return nil, err
+	}
+	if ga.ReflectMetadata, err = parseGrpcurlHeaders(addlHeaders, reflHeaders); err != nil {
+		return nil, err
+	}
+
+	ga.Protos = protoFiles
+	ga.ImportPaths = importPaths
+	ga.Protosets = protoset
 
-	for _, headerValue := range append(addlHeaders, rpcHeaders...) {
-		headerData := strings.Split(headerValue, ":")
+	return &ga, nil
+}
 
-		if len(headerData) != 2 {
+// readFlagFile reads the file given to a flag such as -cacert
+func readFlagFile(flagName, path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s file: %v", flagName, err)
+	}
+	return string(b), nil
+}
+
+// options applies the connection flags to the options of a workspace,
+// reading the cert files they refer to. Settings grpcurl has no flag for are
+// kept.
+func (ga *grpcurlArguments) options(opts options) (options, error) {
+	opts.Addr = ga.Target
+	if ga.Unix && !strings.HasPrefix(opts.Addr, "unix:") {
+		opts.Addr = "unix:" + opts.Addr
+	}
+	opts.Transport = ""
+	opts.Codec = ""
+	// grpcurl rejects unknown fields unless they are allowed
+	opts.InputFormat = ga.Format
+	opts.StrictFields = !ga.AllowUnknownFields
+
+	opts.Reflect = len(ga.Protos) == 0 && len(ga.Protosets) == 0
+	opts.Protos = protos{
+		Files:     ga.Protos,
+		Roots:     ga.ImportPaths,
+		Protosets: ga.Protosets,
+	}
+
+	opts.Plaintext = ga.Plaintext
+	opts.Insecure = ga.Insecure
+	opts.ServerName = ga.ServerName
+	opts.Authority = ga.Authority
+
+	var err error
+	if opts.Rootca, err = readFlagFile("-cacert", ga.CACert); err != nil {
+		return opts, err
+	}
+	if opts.Clientcert, err = readFlagFile("-cert", ga.Cert); err != nil {
+		return opts, err
+	}
+	if opts.Clientkey, err = readFlagFile("-key", ga.Key); err != nil {
+		return opts, err
+	}
+
+	if ga.MaxTime > 0 {
+		opts.Timeout = ga.MaxTime
+	}
+	if ga.ConnectTimeout > 0 {
+		opts.ConnectTimeout = ga.ConnectTimeout
+	}
+	if ga.KeepaliveTime > 0 {
+		opts.Keepalive.Time = ga.KeepaliveTime
+	}
+	return opts, nil
+}
+
+// importGrpcurl returns the workspace and call of the grpcurl command. The
+// workspace is the first grpc one with the same address and settings, if
+// there is one, or else a new one.
+func (a *api) importGrpcurl(command string) (*importedCommand, error) {
+	args, err := parseGrpcurlCommand(command)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing grpcurl command: %v", err)
+	}
+
+	workspaces, err := a.ListWorkspaces()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workspaces: %v", err)
+	}
+	opts, err := importWorkspace(workspaces, func(ws options) bool {
+		return ws.Addr == args.Target && !isHTTPTransport(ws.Transport)
+	}, args.options)
+	if err != nil {
+		return nil, err
+	}
+	return &importedCommand{
+		Workspace:       opts,
+		Method:          "/" + args.Method,
+		Data:            args.Data,
+		Metadata:        args.Metadata,
+		ReflectMetadata: args.ReflectMetadata,
+		template:        args.MsgTemplate && args.Data == "",
+	}, nil
+}
+
+// importWorkspace applies the settings of an imported command to the first
+// matching workspace they leave unchanged, or else to a new workspace, so an
+// import never changes how an existing workspace connects
+func importWorkspace(workspaces []options, match func(options) bool, apply func(options) (options, error)) (options, error) {
+	for _, ws := range workspaces {
+		if !match(ws) {
 			continue
 		}
-		metadata = append(metadata, header{
-			Key: headerData[0],
-			Val: headerData[1],
-		})
+		opts, err := apply(ws)
+		if err != nil {
+			return opts, err
+		}
+		if sameImportSettings(ws, opts) {
+			return opts, nil
+		}
 	}
+	return apply(options{})
+}
 
-	return &grpcurlArguments{
-		Target:   grpcurlArgs[0],
-		Method:   grpcurlArgs[1],
-		Data:     data,
-		Metadata: metadata,
-	}, nil
+// sameImportSettings reports if the settings an import may set are the same
+// in both options, treating unset values as their defaults
+func sameImportSettings(a, b options) bool {
+	or := func(v, def string) string {
+		if v == "" {
+			return def
+		}
+		return v
+	}
+	return a.Addr == b.Addr &&
+		or(a.Transport, transportGRPC) == or(b.Transport, transportGRPC) &&
+		or(a.Codec, codecProto) == or(b.Codec, codecProto) &&
+		or(a.InputFormat, formatJSON) == or(b.InputFormat, formatJSON) &&
+		a.StrictFields == b.StrictFields &&
+		a.Reflect == b.Reflect &&
+		slices.Equal(a.Protos.Files, b.Protos.Files) &&
+		slices.Equal(a.Protos.Roots, b.Protos.Roots) &&
+		slices.Equal(a.Protos.Protosets, b.Protos.Protosets) &&
+		a.Plaintext == b.Plaintext &&
+		a.Insecure == b.Insecure &&
+		a.Rootca == b.Rootca &&
+		a.Clientcert == b.Clientcert &&
+		a.Clientkey == b.Clientkey &&
+		a.ServerName == b.ServerName &&
+		a.Authority == b.Authority &&
+		a.Timeout == b.Timeout &&
+		a.ConnectTimeout == b.ConnectTimeout &&
+		a.Keepalive == b.Keepalive
 }
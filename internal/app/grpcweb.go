@@ -0,0 +1,339 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	statuspb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	grpcWebContentType     = "application/grpc-web+proto"
+	grpcWebTextContentType = "application/grpc-web-text+proto"
+
+	grpcWebFlagTrailer    = 0x80
+	grpcWebFlagCompressed = 0x01
+)
+
+// webClient sends calls using the gRPC-Web wire format over HTTP/1.1, in
+// either binary or base64 text mode. As the whole request body is sent
+// before the response is read, only unary and server streaming calls are
+// supported.
+type webClient struct {
+	http    *http.Client
+	baseURL string
+	text    bool
+	handler stats.Handler
+}
+
+func newWebClient(o options, h stats.Handler) (*webClient, error) {
+	base, err := httpBaseURL(o)
+	if err != nil {
+		return nil, err
+	}
+	hc, err := newHTTPClient(o)
+	if err != nil {
+		return nil, err
+	}
+	return &webClient{
+		http:    hc,
+		baseURL: base,
+		text:    o.Transport == transportGRPCWebText,
+		handler: h,
+	}, nil
+}
+
+func (w *webClient) invoke(ctx context.Context, method string, req, resp proto.Message) error {
+	s, err := w.newStream(ctx, &grpc.StreamDesc{StreamName: method}, method)
+	if err != nil {
+		return err
+	}
+	if err := s.SendMsg(req); err != nil {
+		return err
+	}
+	if err := s.RecvMsg(resp); err != nil {
+		if err == io.EOF {
+			return status.Error(codes.Internal, "grpc-web: no response message received")
+		}
+		return err
+	}
+	extra := resp.ProtoReflect().New().Interface()
+	if err := s.RecvMsg(extra); err != io.EOF {
+		if err == nil {
+			return status.Error(codes.Internal, "grpc-web: too many response messages for unary call")
+		}
+		return err
+	}
+	return nil
+}
+
+func (w *webClient) newStream(ctx context.Context, desc *grpc.StreamDesc, method string) (grpc.ClientStream, error) {
+	if desc.ClientStreams {
+		return nil, status.Error(codes.Unimplemented, "grpc-web does not support client or bidirectional streaming")
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	ctx, st := newHTTPStats(ctx, w.handler, method, false, desc.ServerStreams)
+	return &webStream{
+		client: w,
+		ctx:    ctx,
+		cancel: cancel,
+		method: method,
+		stats:  st,
+	}, nil
+}
+
+// webStream implements grpc.ClientStream for a single gRPC-Web call
+type webStream struct {
+	client *webClient
+	ctx    context.Context
+	cancel context.CancelFunc
+	method string
+	stats  *httpStats
+
+	resp    *http.Response
+	body    io.Reader
+	header  metadata.MD
+	trailer metadata.MD
+	done    bool
+	err     error
+}
+
+func (s *webStream) Header() (metadata.MD, error) {
+	if s.resp == nil {
+		return nil, errors.New("grpc-web: request not sent")
+	}
+	return s.header, nil
+}
+
+func (s *webStream) Trailer() metadata.MD {
+	return s.trailer
+}
+
+func (s *webStream) CloseSend() error {
+	return nil
+}
+
+func (s *webStream) Context() context.Context {
+	return s.ctx
+}
+
+// SendMsg sends the request. gRPC-Web only allows a single request message,
+// which is sent as the complete HTTP request body.
+func (s *webStream) SendMsg(m any) error {
+	if s.resp != nil || s.done {
+		return errors.New("grpc-web: request already sent")
+	}
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return fmt.Errorf("grpc-web: message is not a proto message: %T", m)
+	}
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return s.finish(nil, status.Errorf(codes.Internal, "grpc-web: failed to marshal request: %v", err))
+	}
+
+	var body bytes.Buffer
+	writeEnvelope(&body, 0, data)
+	contentType := grpcWebContentType
+	if s.client.text {
+		contentType = grpcWebTextContentType
+		encoded := base64.StdEncoding.EncodeToString(body.Bytes())
+		body.Reset()
+		body.WriteString(encoded)
+	}
+	wireLen := body.Len()
+
+	req, err := http.NewRequestWithContext(s.ctx, http.MethodPost, s.client.baseURL+s.method, &body)
+	if err != nil {
+		return s.finish(nil, status.Error(codes.Internal, err.Error()))
+	}
+	md := setRequestMetadata(s.ctx, req.Header)
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Accept", contentType)
+	req.Header.Set("X-Grpc-Web", "1")
+	req.Header.Set("X-User-Agent", fmt.Sprintf("%s/%s grpc-web", appName, semver))
+	if dl, ok := s.ctx.Deadline(); ok {
+		req.Header.Set("Grpc-Timeout", encodeTimeout(time.Until(dl)))
+	}
+
+	s.stats.outHeader(md)
+	s.stats.outPayload(msg, data, wireLen)
+
+	resp, err := s.client.http.Do(req)
+	if err != nil {
+		code := codes.Unavailable
+		if s.ctx.Err() != nil {
+			code = status.FromContextError(s.ctx.Err()).Code()
+		}
+		return s.finish(nil, status.Error(code, err.Error()))
+	}
+	s.resp = resp
+	s.header = metadataFromHeader(resp.Header)
+	s.stats.inHeader(s.header, 0)
+
+	if resp.StatusCode != http.StatusOK {
+		if st, ok := statusFromTrailer(s.header); ok {
+			return s.finish(s.header, st.Err())
+		}
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return s.finish(nil, statusFromHTTP(resp.StatusCode, strings.TrimSpace(string(msg))).Err())
+	}
+
+	s.body = resp.Body
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "application/grpc-web-text") {
+		s.body = &base64Reader{r: resp.Body}
+	}
+	return nil
+}
+
+func (s *webStream) RecvMsg(m any) error {
+	if s.done {
+		return s.result()
+	}
+	if s.body == nil {
+		return errors.New("grpc-web: request not sent")
+	}
+
+	flags, data, err := readEnvelope(s.body)
+	if err == io.EOF {
+		// A trailers-only response carries the status in the headers
+		if st, ok := statusFromTrailer(s.header); ok {
+			return s.finish(s.header, st.Err())
+		}
+		return s.finish(nil, status.Error(codes.Internal, "grpc-web: response ended without trailers"))
+	}
+	if err != nil {
+		if s.ctx.Err() != nil {
+			return s.finish(nil, status.FromContextError(s.ctx.Err()).Err())
+		}
+		return s.finish(nil, status.Errorf(codes.Internal, "grpc-web: %v", err))
+	}
+
+	if flags&grpcWebFlagTrailer != 0 {
+		trailer := parseWebTrailer(data)
+		st, ok := statusFromTrailer(trailer)
+		if !ok {
+			st = status.New(codes.Internal, "grpc-web: missing grpc-status in trailers")
+		}
+		return s.finish(trailer, st.Err())
+	}
+	if flags&grpcWebFlagCompressed != 0 {
+		return s.finish(nil, status.Error(codes.Internal, "grpc-web: compressed messages are not supported"))
+	}
+
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return fmt.Errorf("grpc-web: message is not a proto message: %T", m)
+	}
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return s.finish(nil, status.Errorf(codes.Internal, "grpc-web: failed to unmarshal response: %v", err))
+	}
+	s.stats.inPayload(msg, data, len(data)+envelopeHeaderLen)
+	return nil
+}
+
+// finish records the final status of the call and releases its resources.
+// It returns the value RecvMsg should return from then on.
+func (s *webStream) finish(trailer metadata.MD, err error) error {
+	if s.done {
+		return s.result()
+	}
+	s.done = true
+	s.err = err
+	s.trailer = withoutReservedKeys(trailer)
+	if trailer != nil {
+		s.stats.inTrailer(s.trailer)
+	}
+	s.stats.end(s.trailer, err)
+	if s.resp != nil {
+		s.resp.Body.Close()
+	}
+	s.cancel()
+	if err != nil {
+		return err
+	}
+	return s.result()
+}
+
+func (s *webStream) result() error {
+	if s.err != nil {
+		return s.err
+	}
+	return io.EOF
+}
+
+// parseWebTrailer parses the HTTP/1 style header block of a trailer frame
+func parseWebTrailer(data []byte) metadata.MD {
+	md := metadata.MD{}
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimRight(line, "\r")
+		k, v, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		k = strings.ToLower(strings.TrimSpace(k))
+		v = strings.TrimSpace(v)
+		if strings.HasSuffix(k, "-bin") {
+			if b, err := decodeBinHeader(v); err == nil {
+				v = string(b)
+			}
+		}
+		md.Append(k, v)
+	}
+	return md
+}
+
+// statusFromTrailer builds the call status from the grpc-status,
+// grpc-message and grpc-status-details-bin keys, if present.
+func statusFromTrailer(md metadata.MD) (*status.Status, bool) {
+	vals := md.Get("grpc-status")
+	if len(vals) == 0 {
+		return nil, false
+	}
+
+	if details := md.Get("grpc-status-details-bin"); len(details) > 0 {
+		st := &statuspb.Status{}
+		if err := proto.Unmarshal([]byte(details[0]), st); err == nil {
+			return status.FromProto(st), true
+		}
+	}
+
+	code, err := strconv.Atoi(strings.TrimSpace(vals[0]))
+	if err != nil {
+		return status.New(codes.Unknown, fmt.Sprintf("invalid grpc-status: %q", vals[0])), true
+	}
+	var msg string
+	if msgs := md.Get("grpc-message"); len(msgs) > 0 {
+		msg = msgs[0]
+		if m, err := url.PathUnescape(msg); err == nil {
+			msg = m
+		}
+	}
+	return status.New(codes.Code(code), msg), true
+}
+
+func withoutReservedKeys(md metadata.MD) metadata.MD {
+	if md == nil {
+		return nil
+	}
+	out := md.Copy()
+	for _, k := range []string{"grpc-status", "grpc-message", "grpc-status-details-bin"} {
+		delete(out, k)
+	}
+	return out
+}
@@ -0,0 +1,304 @@
+package app
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+	"google.golang.org/grpc/metadata"
+)
+
+const harVersion = "1.2"
+
+// harLog is a HAR document of recorded calls. The standard fields describe
+// each call as a single HTTP exchange, so HAR viewers can show it, while the
+// complete recording is kept in the custom _grpc field for importing.
+type harLog struct {
+	Log harBody `json:"log"`
+}
+
+type harBody struct {
+	Version string     `json:"version"`
+	Creator harCreator `json:"creator"`
+	Entries []harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harEntry struct {
+	StartedDateTime time.Time     `json:"startedDateTime"`
+	Time            float64       `json:"time"`
+	Request         harRequest    `json:"request"`
+	Response        harResponse   `json:"response"`
+	Cache           struct{}      `json:"cache"`
+	Timings         harTimings    `json:"timings"`
+	GRPC            *historyEntry `json:"_grpc,omitempty"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+// harTimings are in milliseconds; -1 is for phases that don't apply
+type harTimings struct {
+	Blocked float64 `json:"blocked"`
+	DNS     float64 `json:"dns"`
+	Connect float64 `json:"connect"`
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+func harHeaders(mds ...metadata.MD) []harNameValue {
+	hs := []harNameValue{}
+	for _, md := range mds {
+		keys := make([]string, 0, len(md))
+		for k := range md {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			for _, v := range md[k] {
+				hs = append(hs, harNameValue{Name: k, Value: v})
+			}
+		}
+	}
+	return hs
+}
+
+// harMessages returns the JSON of the messages, one per line, and their
+// total size on the wire
+func harMessages(msgs []recordedMessage) (string, int) {
+	var text []string
+	size := 0
+	for _, m := range msgs {
+		text = append(text, m.JSON)
+		size += m.WireSize
+	}
+	return strings.Join(text, "\n"), size
+}
+
+// harTimingsOf splits the duration of the call into sending the requests,
+// waiting for the first response and receiving the rest
+func harTimingsOf(e *historyEntry) harTimings {
+	sent := 0.0
+	for _, m := range e.messages(true) {
+		sent = m.Offset
+	}
+	first := e.Duration
+	for _, p := range e.Phases {
+		if (p.Name == "in_header" || p.Name == "in_payload") && p.Offset < first {
+			first = p.Offset
+		}
+	}
+	if first < sent {
+		// Streams receive responses while still sending
+		first = sent
+	}
+	// Rounded to microseconds, the resolution of the recording
+	round := func(v float64) float64 { return math.Round(v*1000) / 1000 }
+	return harTimings{
+		Blocked: -1,
+		DNS:     -1,
+		Connect: -1,
+		Send:    round(sent),
+		Wait:    round(first - sent),
+		Receive: round(e.Duration - first),
+	}
+}
+
+func newHAREntry(e historyEntry) harEntry {
+	reqText, reqSize := harMessages(e.messages(true))
+	respText, respSize := harMessages(e.messages(false))
+	return harEntry{
+		StartedDateTime: e.Started,
+		Time:            e.Duration,
+		Request: harRequest{
+			Method:      "POST",
+			URL:         "grpc://" + e.Addr + e.Method,
+			HTTPVersion: "HTTP/2",
+			Cookies:     []harNameValue{},
+			Headers:     harHeaders(e.Header),
+			QueryString: []harNameValue{},
+			PostData:    &harPostData{MimeType: "application/json", Text: reqText},
+			HeadersSize: -1,
+			BodySize:    reqSize,
+		},
+		Response: harResponse{
+			// gRPC reports its status in the trailers of a 200 response
+			Status:      200,
+			StatusText:  e.Status,
+			HTTPVersion: "HTTP/2",
+			Cookies:     []harNameValue{},
+			Headers:     harHeaders(e.ResponseHeader, e.Trailer),
+			Content:     harContent{Size: respSize, MimeType: "application/json", Text: respText},
+			HeadersSize: -1,
+			BodySize:    respSize,
+		},
+		Timings: harTimingsOf(&e),
+		GRPC:    &e,
+	}
+}
+
+// sessionHistory returns the calls of every workspace recorded since the
+// app started
+func (a *api) sessionHistory() ([]historyEntry, error) {
+	items, err := a.store.list([]byte(historyKeyPrefix))
+	if err != nil {
+		return nil, err
+	}
+	var entries []historyEntry
+	for _, item := range items {
+		var e historyEntry
+		if err := gob.NewDecoder(bytes.NewBuffer(item)).Decode(&e); err != nil {
+			return nil, err
+		}
+		if !e.Imported && !e.Started.Before(a.started) {
+			entries = append(entries, e)
+		}
+	}
+	return entries, nil
+}
+
+// ExportHAR returns a HAR document of the recorded calls of the current
+// workspace with the IDs, or of every call made in this session if there
+// are none. Entries are ordered by the time the calls started.
+func (a *api) ExportHAR(ids []string) (doc string, rerr error) {
+	defer func() {
+		if rerr != nil {
+			const errTitle = "Failed to export session log"
+			runtime.LogError(a.ctx, rerr.Error())
+			a.emitError(errTitle, rerr.Error())
+		}
+	}()
+
+	var entries []historyEntry
+	if len(ids) == 0 {
+		var err error
+		if entries, err = a.sessionHistory(); err != nil {
+			return "", fmt.Errorf("failed to list history: %v", err)
+		}
+	}
+	for _, id := range ids {
+		e, err := a.GetHistoryEntry(id)
+		if err != nil {
+			return "", fmt.Errorf("history entry %q: %v", id, err)
+		}
+		entries = append(entries, *e)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Started.Before(entries[j].Started)
+	})
+
+	har := harLog{Log: harBody{
+		Version: harVersion,
+		Creator: harCreator{Name: appName, Version: semver},
+		Entries: []harEntry{},
+	}}
+	for _, e := range entries {
+		har.Log.Entries = append(har.Log.Entries, newHAREntry(e))
+	}
+	b, err := json.MarshalIndent(har, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode session log: %v", err)
+	}
+	return string(b), nil
+}
+
+// ImportHAR adds the calls of a HAR document exported by ExportHAR to the
+// history of their addresses, returning the number kept. Entries without a
+// recording, such as those of other tools, are skipped. Calls keep the IDs
+// of when they were made, so those older than the newest maxHistoryEntries
+// of their address are pruned straight away.
+func (a *api) ImportHAR(doc string) (n int, rerr error) {
+	defer func() {
+		if rerr != nil {
+			const errTitle = "Failed to import session log"
+			runtime.LogError(a.ctx, rerr.Error())
+			a.emitError(errTitle, rerr.Error())
+		}
+	}()
+
+	var har harLog
+	if err := json.Unmarshal([]byte(doc), &har); err != nil {
+		return 0, fmt.Errorf("invalid HAR document: %v", err)
+	}
+	skipped := 0
+	var imported []*historyEntry
+	for _, he := range har.Log.Entries {
+		e := he.GRPC
+		if e == nil || e.ID == "" || e.Addr == "" || e.Method == "" {
+			skipped++
+			continue
+		}
+		e.CallID = ""
+		e.Imported = true
+		a.saveHistory(*e)
+		imported = append(imported, e)
+	}
+	if len(imported) == 0 {
+		return 0, errors.New("the document has no recorded gRPC calls")
+	}
+	if skipped > 0 {
+		runtime.LogWarning(a.ctx, fmt.Sprintf("skipped %d HAR entries without a gRPC recording", skipped))
+	}
+
+	for _, e := range imported {
+		if _, err := a.store.get(historyKey(e.Addr, e.ID)); err == nil {
+			n++
+		}
+	}
+	if pruned := len(imported) - n; pruned > 0 {
+		runtime.LogWarning(a.ctx, fmt.Sprintf("%d imported calls were older than the %d kept for their address", pruned, maxHistoryEntries))
+	}
+	if n == 0 {
+		return 0, fmt.Errorf("all %d calls are older than the %d kept for their address", len(imported), maxHistoryEntries)
+	}
+	return n, nil
+}
@@ -0,0 +1,215 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+const healthCheckTimeout = 5 * time.Second
+
+// healthStatus is the health of a service of a workspace, or of the server as
+// a whole if Service is empty. Status is the serving status reported by the
+// server, or empty if the check failed with Error.
+type healthStatus struct {
+	WorkspaceID string    `json:"workspace_id"`
+	Service     string    `json:"service"`
+	Status      string    `json:"status"`
+	Error       string    `json:"error"`
+	Watching    bool      `json:"watching"`
+	Time        time.Time `json:"time"`
+}
+
+func healthWatchKey(workspaceID, service string) string {
+	return workspaceID + "/" + service
+}
+
+// healthServices returns the services of the proto files that are checked,
+// which excludes the reflection and health services
+func healthServices(files *protoregistry.Files) []string {
+	var names []string
+	if files == nil {
+		return names
+	}
+	files.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		sds := fd.Services()
+		for i := 0; i < sds.Len(); i++ {
+			name := string(sds.Get(i).FullName())
+			if strings.HasPrefix(name, "grpc.reflection.") || name == healthpb.Health_ServiceDesc.ServiceName {
+				continue
+			}
+			names = append(names, name)
+		}
+		return true
+	})
+	sort.Strings(names)
+	return names
+}
+
+// healthClient returns a client for the health service of the connection
+func healthClient(conn *connection) (healthpb.HealthClient, error) {
+	cc := conn.client.grpcConn()
+	if cc == nil {
+		return nil, errors.New("health checking needs a grpc connection")
+	}
+	return healthpb.NewHealthClient(cc), nil
+}
+
+// checkService calls Health/Check for the service. The call is internal, so
+// it isn't shown as a call of the workspace.
+func checkService(hc healthpb.HealthClient, workspaceID, service string) (healthStatus, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), healthCheckTimeout)
+	defer cancel()
+	ctx = context.WithValue(ctx, ctxInternalKey{}, struct{}{})
+
+	st := healthStatus{WorkspaceID: workspaceID, Service: service}
+	resp, err := hc.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+	st.Time = time.Now()
+	if status.Code(err) == codes.NotFound {
+		// Services the server doesn't know of are reported as NotFound
+		st.Status = healthpb.HealthCheckResponse_SERVICE_UNKNOWN.String()
+		return st, nil
+	}
+	if err != nil {
+		st.Error = healthError(err)
+		return st, err
+	}
+	st.Status = resp.GetStatus().String()
+	return st, nil
+}
+
+func healthError(err error) string {
+	s := status.Convert(err)
+	if s.Code() == codes.Unimplemented {
+		return "the server does not support health checking"
+	}
+	return s.Message()
+}
+
+// checkHealth checks the server as a whole and then each of its services,
+// sending each result as an event. The services aren't checked if the server
+// doesn't support health checking.
+func (a *api) checkHealth(conn *connection) []healthStatus {
+	hc, err := healthClient(conn)
+	if err != nil {
+		return nil
+	}
+
+	overall, err := checkService(hc, conn.id, "")
+	runtime.EventsEmit(a.ctx, eventHealthChanged, overall)
+	results := []healthStatus{overall}
+	if status.Code(err) == codes.Unimplemented {
+		return results
+	}
+
+	var wg sync.WaitGroup
+	services := healthServices(conn.files())
+	statuses := make([]healthStatus, len(services))
+	for i, name := range services {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			statuses[i], _ = checkService(hc, conn.id, name)
+			runtime.EventsEmit(a.ctx, eventHealthChanged, statuses[i])
+		}(i, name)
+	}
+	wg.Wait()
+	return append(results, statuses...)
+}
+
+// CheckHealth calls grpc.health.v1.Health/Check on the current workspace for
+// the server as a whole and each of its services. Each result is also sent as
+// an event.
+func (a *api) CheckHealth() ([]healthStatus, error) {
+	conn := a.current()
+	if conn == nil {
+		return nil, errNoConn
+	}
+	if _, err := healthClient(conn); err != nil {
+		return nil, err
+	}
+	return a.checkHealth(conn), nil
+}
+
+// WatchHealth streams the health of the service, or of the server as a whole
+// if empty, from grpc.health.v1.Health/Watch on the current workspace. Each
+// change is sent as an event until StopWatchHealth is called or the stream
+// fails.
+func (a *api) WatchHealth(service string) (rerr error) {
+	defer func() {
+		if rerr != nil {
+			const errTitle = "Unable to watch health"
+			runtime.LogError(a.ctx, rerr.Error())
+			a.emitError(errTitle, rerr.Error())
+		}
+	}()
+
+	conn := a.current()
+	if conn == nil {
+		return errNoConn
+	}
+	hc, err := healthClient(conn)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ctx = context.WithValue(ctx, ctxInternalKey{}, struct{}{})
+	stream, err := hc.Watch(ctx, &healthpb.HealthCheckRequest{Service: service})
+	if err != nil {
+		cancel()
+		return err
+	}
+
+	key := healthWatchKey(conn.id, service)
+	a.mu.Lock()
+	if prev := a.healthWatches[key]; prev != nil {
+		prev()
+	}
+	a.healthWatches[key] = cancel
+	a.mu.Unlock()
+
+	go func() {
+		defer cancel()
+		for {
+			resp, err := stream.Recv()
+			st := healthStatus{WorkspaceID: conn.id, Service: service, Watching: true, Time: time.Now()}
+			if err != nil {
+				if ctx.Err() != nil {
+					// Stopped by StopWatchHealth or a newer watch
+					return
+				}
+				st.Watching = false
+				st.Error = healthError(err)
+				runtime.EventsEmit(a.ctx, eventHealthChanged, st)
+				return
+			}
+			st.Status = resp.GetStatus().String()
+			runtime.EventsEmit(a.ctx, eventHealthChanged, st)
+		}
+	}()
+	return nil
+}
+
+// StopWatchHealth stops watching the health of the service of the current
+// workspace
+func (a *api) StopWatchHealth(service string) {
+	key := healthWatchKey(a.currentID(), service)
+	a.mu.Lock()
+	cancel := a.healthWatches[key]
+	delete(a.healthWatches, key)
+	a.mu.Unlock()
+	if cancel != nil {
+		cancel()
+	}
+}
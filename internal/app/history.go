@@ -0,0 +1,452 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/runtime/protoiface"
+)
+
+const maxHistoryEntries = 100
+
+var errHistoryNotFound = errors.New("app: history entry not found")
+
+type ctxRecorderKey struct{}
+
+// recordedMessage is a request or response message of a call, with the time
+// it was sent or received relative to the start of the call
+type recordedMessage struct {
+	Outbound bool    `json:"outbound"`
+	Offset   float64 `json:"offset_ms"`
+	JSON     string  `json:"json"`
+
+	// Sizes are in bytes: the encoded message, after compression, and on
+	// the wire including the message framing
+	Size           int `json:"size"`
+	CompressedSize int `json:"compressed_size"`
+	WireSize       int `json:"wire_size"`
+}
+
+// callPhase is a stats event of a call, relative to the start of the call
+type callPhase struct {
+	Name   string  `json:"name"`
+	Offset float64 `json:"offset_ms"`
+}
+
+// historyEntry is the recording of a complete call
+type historyEntry struct {
+	ID             string            `json:"id"`
+	CallID         string            `json:"call_id"`
+	Addr           string            `json:"addr"`
+	Method         string            `json:"method"`
+	ClientStream   bool              `json:"client_stream"`
+	ServerStream   bool              `json:"server_stream"`
+	Started        time.Time         `json:"started"`
+	Duration       float64           `json:"duration_ms"`
+	Header         metadata.MD       `json:"header"`
+	ResponseHeader metadata.MD       `json:"response_header"`
+	Trailer        metadata.MD       `json:"trailer"`
+	Status         string            `json:"status"`
+	StatusCode     int32             `json:"status_code"`
+	StatusMessage  string            `json:"status_message"`
+	Messages       []recordedMessage `json:"messages"`
+	Phases         []callPhase       `json:"phases"`
+	// Imported is set for entries imported from a session log
+	Imported bool `json:"imported"`
+}
+
+type replayResult struct {
+	CallID         string         `json:"call_id"`
+	HistoryID      string         `json:"history_id"`
+	ExpectedStatus string         `json:"expected_status"`
+	Status         string         `json:"status"`
+	Equal          bool           `json:"equal"`
+	Messages       []responseDiff `json:"messages"`
+	Error          string         `json:"error"`
+}
+
+// recorder builds the history entry of a call from its stats events
+type recorder struct {
+	mu    sync.Mutex
+	entry historyEntry
+	begin time.Time
+}
+
+func newRecorder(addr, method string, md protoreflect.MethodDescriptor) *recorder {
+	return &recorder{entry: historyEntry{
+		Addr:         addr,
+		Method:       method,
+		ClientStream: md.IsStreamingClient(),
+		ServerStream: md.IsStreamingServer(),
+	}}
+}
+
+func recorderFromContext(ctx context.Context) *recorder {
+	r, _ := ctx.Value(ctxRecorderKey{}).(*recorder)
+	return r
+}
+
+// handle records the stats event. Every attempt of a retried call ends, so
+// the entry is saved once the call has ended rather than here.
+func (r *recorder) handle(stat stats.RPCStats) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	e := &r.entry
+	switch s := stat.(type) {
+	case *stats.Begin:
+		if r.begin.IsZero() {
+			r.begin = s.BeginTime
+			e.Started = s.BeginTime
+			e.ID = fmt.Sprintf("%020d", s.BeginTime.UnixNano())
+		}
+		// Only the last attempt of a retried call is kept
+		e.Messages = nil
+		e.ResponseHeader = nil
+		e.Trailer = nil
+		e.Phases = nil
+		r.addPhase("begin", s.BeginTime)
+	case *stats.OutHeader:
+		e.Header = s.Header.Copy()
+		r.addPhase("out_header", time.Now())
+	case *stats.OutPayload:
+		r.addMessage(true, s.SentTime, s.Payload, s.Length, s.CompressedLength, s.WireLength)
+		r.addPhase("out_payload", s.SentTime)
+	case *stats.InHeader:
+		e.ResponseHeader = s.Header.Copy()
+		r.addPhase("in_header", time.Now())
+	case *stats.InPayload:
+		r.addMessage(false, s.RecvTime, s.Payload, s.Length, s.CompressedLength, s.WireLength)
+		r.addPhase("in_payload", s.RecvTime)
+	case *stats.InTrailer:
+		e.Trailer = s.Trailer.Copy()
+		r.addPhase("in_trailer", time.Now())
+	case *stats.End:
+		st := status.Convert(s.Error)
+		e.Duration = ms(s.EndTime.Sub(r.begin))
+		r.addPhase("end", s.EndTime)
+		e.Status = st.Code().String()
+		e.StatusCode = int32(st.Code())
+		e.StatusMessage = st.Message()
+	}
+}
+
+// addMessage must be called with r.mu held
+func (r *recorder) addMessage(outbound bool, at time.Time, payload interface{}, size, compressed, wire int) {
+	data, err := payloadJSON(payload)
+	if err != nil {
+		data = fmt.Sprintf("%q", err.Error())
+	}
+	r.entry.Messages = append(r.entry.Messages, recordedMessage{
+		Outbound:       outbound,
+		Offset:         ms(at.Sub(r.begin)),
+		JSON:           data,
+		Size:           size,
+		CompressedSize: compressed,
+		WireSize:       wire,
+	})
+}
+
+// addPhase must be called with r.mu held. Headers and trailers have no
+// timestamp in their stats, so they're recorded as they're handled.
+func (r *recorder) addPhase(name string, at time.Time) {
+	r.entry.Phases = append(r.entry.Phases, callPhase{Name: name, Offset: ms(at.Sub(r.begin))})
+}
+
+func (r *recorder) snapshot() historyEntry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	e := r.entry
+	e.Messages = append([]recordedMessage(nil), e.Messages...)
+	return e
+}
+
+func payloadJSON(payload interface{}) (string, error) {
+	msg, ok := payload.(proto.Message)
+	if !ok {
+		msgV1, ok := payload.(protoiface.MessageV1)
+		if !ok {
+			return "", fmt.Errorf("payload is not a proto message: %T", payload)
+		}
+		msg = protoadapt.MessageV2Of(msgV1)
+	}
+	b, err := protojson.Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func (e *historyEntry) messages(outbound bool) []recordedMessage {
+	var msgs []recordedMessage
+	for _, m := range e.Messages {
+		if m.Outbound == outbound {
+			msgs = append(msgs, m)
+		}
+	}
+	return msgs
+}
+
+// requestHeaders returns the recorded metadata that can be sent again, which
+// excludes the headers set by the transport
+func (e *historyEntry) requestHeaders() headers {
+	return userHeaders(e.Header)
+}
+
+// userHeaders returns the metadata that wasn't set by the transport
+func userHeaders(md metadata.MD) headers {
+	var hs headers
+	for k, vs := range md {
+		if reservedHeader(k) {
+			continue
+		}
+		for _, v := range vs {
+			hs = append(hs, header{Key: k, Val: v})
+		}
+	}
+	return hs
+}
+
+// reservedHeader reports if the metadata key is set by the transport
+func reservedHeader(k string) bool {
+	if strings.HasPrefix(k, ":") || strings.HasPrefix(k, "grpc-") {
+		return true
+	}
+	switch k {
+	case "content-type", "user-agent", "te":
+		return true
+	}
+	return false
+}
+
+func historyKey(addr, id string) []byte {
+	return []byte(historyKeyPrefix + hash(addr) + "_" + id)
+}
+
+// saveHistory stores the entry, removing the oldest entries of the addr once
+// there are more than maxHistoryEntries
+func (a *api) saveHistory(e historyEntry) {
+	if e.ID == "" {
+		return
+	}
+	var val bytes.Buffer
+	if err := gob.NewEncoder(&val).Encode(e); err != nil {
+		runtime.LogError(a.ctx, fmt.Sprintf("failed to encode history entry: %v", err))
+		return
+	}
+	if err := a.store.set(historyKey(e.Addr, e.ID), val.Bytes()); err != nil {
+		runtime.LogError(a.ctx, fmt.Sprintf("failed to store history entry: %v", err))
+		return
+	}
+
+	keys, err := a.store.keys([]byte(historyKeyPrefix + hash(e.Addr) + "_"))
+	if err != nil {
+		runtime.LogError(a.ctx, fmt.Sprintf("failed to list history: %v", err))
+	}
+	for len(keys) > maxHistoryEntries {
+		a.store.del(keys[0])
+		keys = keys[1:]
+	}
+	runtime.EventsEmit(a.ctx, eventHistoryAdded, e)
+}
+
+func (a *api) getHistoryEntry(addr, id string) (*historyEntry, error) {
+	val, err := a.store.get(historyKey(addr, id))
+	if err == errKeyNotFound {
+		return nil, errHistoryNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var e historyEntry
+	if err := gob.NewDecoder(bytes.NewBuffer(val)).Decode(&e); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// ListHistory returns the recorded calls of the current workspace, newest
+// first
+func (a *api) ListHistory() ([]historyEntry, error) {
+	opts, err := a.GetWorkspaceOptions()
+	if err != nil {
+		return nil, err
+	}
+	items, err := a.store.list([]byte(historyKeyPrefix + hash(opts.Addr) + "_"))
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]historyEntry, 0, len(items))
+	for i := len(items) - 1; i >= 0; i-- {
+		var e historyEntry
+		if err := gob.NewDecoder(bytes.NewBuffer(items[i])).Decode(&e); err != nil {
+			return entries, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// GetHistoryEntry returns a recorded call of the current workspace
+func (a *api) GetHistoryEntry(id string) (*historyEntry, error) {
+	opts, err := a.GetWorkspaceOptions()
+	if err != nil {
+		return nil, err
+	}
+	return a.getHistoryEntry(opts.Addr, id)
+}
+
+// DeleteHistoryEntry removes a recorded call of the current workspace
+func (a *api) DeleteHistoryEntry(id string) error {
+	opts, err := a.GetWorkspaceOptions()
+	if err != nil {
+		return err
+	}
+	return a.store.del(historyKey(opts.Addr, id))
+}
+
+// ClearHistory removes all the recorded calls of the current workspace
+func (a *api) ClearHistory() error {
+	opts, err := a.GetWorkspaceOptions()
+	if err != nil {
+		return err
+	}
+	keys, err := a.store.keys([]byte(historyKeyPrefix + hash(opts.Addr) + "_"))
+	if err != nil {
+		return err
+	}
+	for _, k := range keys {
+		if err := a.store.del(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReplayHistory sends the requests of a recorded call of the current
+// workspace again, to the workspace or the current workspace if empty. With
+// timing the requests of a client stream are sent with their original
+// delays, otherwise as fast as possible. When the call ends its responses
+// are compared to the recording with the diff options and sent as an event.
+func (a *api) ReplayHistory(id, workspaceID string, timing bool, rawOpts interface{}) (callID string, rerr error) {
+	defer func() {
+		if rerr != nil {
+			const errTitle = "Unable to replay call"
+			runtime.LogError(a.ctx, rerr.Error())
+			a.emitError(errTitle, rerr.Error())
+		}
+	}()
+
+	var opts diffOptions
+	if err := mapstructure.Decode(rawOpts, &opts); err != nil {
+		return "", fmt.Errorf("failed to decode diff options: %v", err)
+	}
+	e, err := a.GetHistoryEntry(id)
+	if err != nil {
+		return "", err
+	}
+	if workspaceID == "" {
+		workspaceID = a.currentID()
+	}
+	c, err := a.openCall(workspaceID, e.Method, e.requestHeaders())
+	if err != nil {
+		return "", err
+	}
+
+	// Recorded messages are JSON, whatever the format of the workspace
+	format := requestFormat{format: formatJSON, strict: c.conn.opts.StrictFields}
+	var reqs []proto.Message
+	for _, m := range e.messages(true) {
+		req, err := format.decode(c.desc.Input(), []byte(m.JSON))
+		if err != nil {
+			c.cancel()
+			return "", fmt.Errorf("failed to unmarshal recorded request: %v", err)
+		}
+		if err := a.validateRequest(c.conn, e.Method, req); err != nil {
+			c.cancel()
+			return "", err
+		}
+		reqs = append(reqs, req)
+	}
+	if len(reqs) == 0 && !c.desc.IsStreamingClient() {
+		c.cancel()
+		return "", errors.New("recording has no request message")
+	}
+
+	var first proto.Message
+	if !c.desc.IsStreamingClient() {
+		first = reqs[0]
+	}
+	a.trackCall(c, nil)
+
+	go func() {
+		if c.desc.IsStreamingClient() {
+			go a.replayRequests(c, e.messages(true), reqs, timing)
+		}
+		err := a.runCall(c, first)
+		if err != nil {
+			const errTitle = "Unable to send request"
+			runtime.LogError(a.ctx, err.Error())
+			runtime.EventsEmit(a.ctx, eventError, errorMsg{errTitle, err.Error()}, c.id)
+		}
+
+		replayed := recorderFromContext(c.ctx).snapshot()
+		res := compareReplay(c.desc.Output(), e, &replayed, opts)
+		res.CallID = c.id
+		runtime.EventsEmit(a.ctx, eventReplayEnded, res)
+	}()
+
+	return c.id, nil
+}
+
+// replayRequests sends the requests of a client stream, then closes it
+func (a *api) replayRequests(c *call, recorded []recordedMessage, reqs []proto.Message, timing bool) {
+	defer c.closeSend()
+	start := time.Now()
+	for i, req := range reqs {
+		if timing {
+			wait := time.Duration(recorded[i].Offset*float64(time.Millisecond)) - time.Since(start)
+			select {
+			case <-time.After(wait):
+			case <-c.done:
+				return
+			}
+		}
+		if err := c.send(req); err != nil {
+			runtime.LogError(a.ctx, fmt.Sprintf("failed to replay request: %v", err))
+			return
+		}
+	}
+}
+
+// compareReplay compares the status and responses of a replayed call to
+// its recording
+func compareReplay(md protoreflect.MessageDescriptor, want, got *historyEntry, opts diffOptions) replayResult {
+	res := replayResult{
+		HistoryID:      want.ID,
+		ExpectedStatus: want.Status,
+		Status:         got.Status,
+	}
+	diffs, equal, err := diffResponses(md, md, want.messages(false), got.messages(false), opts)
+	if err != nil {
+		res.Error = err.Error()
+	}
+	res.Messages = diffs
+	res.Equal = err == nil && equal && res.ExpectedStatus == res.Status
+	return res
+}
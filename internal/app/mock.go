@@ -0,0 +1,629 @@
+package app
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	v1reflectiongrpc "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	v1alphareflectiongrpc "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+const defaultMockAddr = "localhost:5002"
+
+// mockOptions configure the mock server
+type mockOptions struct {
+	Addr string `json:"addr"`
+}
+
+// mockResponse is a single canned response. Body is the JSON of the response
+// message and may be a template, e.g. {"name": "{{.Request.name}}"}. Delay is
+// the number of seconds to wait before it is sent.
+type mockResponse struct {
+	Body  string  `json:"body"`
+	Delay float64 `json:"delay"`
+}
+
+// mockFixture is how the mock server answers a method:
+//
+//	unary and client stream: the first response, or an empty message
+//	server stream:           every response is sent in order
+//	bidirectional stream:    every response is sent for each request
+//
+// Latency is the number of seconds to wait before responding. A non-zero Code
+// ends the call with that status after any responses have been sent, so an
+// error is injected by setting Code without any responses.
+type mockFixture struct {
+	Method    string         `json:"method"`
+	Responses []mockResponse `json:"responses"`
+	Latency   float64        `json:"latency"`
+	Code      uint32         `json:"code"`
+	Message   string         `json:"message"`
+	Header    headers        `json:"header"`
+	Trailer   headers        `json:"trailer"`
+}
+
+// mockCallData is available to response templates. Requests are decoded from
+// JSON using the proto field names, so {{.Request.type_string}} is the
+// type_string field of the request.
+type mockCallData struct {
+	Request  map[string]interface{}
+	Requests []map[string]interface{} // every request of a client stream
+	Metadata map[string]string
+	Index    int // of the response in the fixture
+}
+
+type mockStatus struct {
+	Addr     string   `json:"addr"`
+	Services []string `json:"services"`
+}
+
+// mockServer answers any method of the loaded proto files with dynamic
+// messages, as configured by the method's fixture
+type mockServer struct {
+	files   *protoregistry.Files
+	fixture func(method string) (*mockFixture, error)
+	gs      *grpc.Server
+	lis     net.Listener
+}
+
+// mockServices lists the services of the proto files for reflection
+type mockServices struct {
+	files *protoregistry.Files
+}
+
+// mockKey is the key of the method's fixture for the workspace address, so
+// each server the workspaces connect to has its own fixtures
+func mockKey(addr, method string) []byte {
+	return []byte(mockKeyPrefix + hash(addr) + "_" + hash(method))
+}
+
+func (s mockServices) GetServiceInfo() map[string]grpc.ServiceInfo {
+	info := make(map[string]grpc.ServiceInfo)
+	s.files.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		sds := fd.Services()
+		for i := 0; i < sds.Len(); i++ {
+			sd := sds.Get(i)
+			var methods []grpc.MethodInfo
+			for j := 0; j < sd.Methods().Len(); j++ {
+				md := sd.Methods().Get(j)
+				methods = append(methods, grpc.MethodInfo{
+					Name:           string(md.Name()),
+					IsClientStream: md.IsStreamingClient(),
+					IsServerStream: md.IsStreamingServer(),
+				})
+			}
+			info[string(sd.FullName())] = grpc.ServiceInfo{Methods: methods, Metadata: fd.Path()}
+		}
+		return true
+	})
+	return info
+}
+
+// mockExtensions registers the extensions of the proto files, which
+// reflection needs to be able to list by message
+func mockExtensions(files *protoregistry.Files) *protoregistry.Types {
+	types := new(protoregistry.Types)
+	var register func(xds protoreflect.ExtensionDescriptors, mds protoreflect.MessageDescriptors)
+	register = func(xds protoreflect.ExtensionDescriptors, mds protoreflect.MessageDescriptors) {
+		for i := 0; i < xds.Len(); i++ {
+			types.RegisterExtension(dynamicpb.NewExtensionType(xds.Get(i)))
+		}
+		for i := 0; i < mds.Len(); i++ {
+			register(mds.Get(i).Extensions(), mds.Get(i).Messages())
+		}
+	}
+	files.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		register(fd.Extensions(), fd.Messages())
+		return true
+	})
+	return types
+}
+
+func newMockServer(files *protoregistry.Files, fixture func(string) (*mockFixture, error)) *mockServer {
+	s := &mockServer{files: files, fixture: fixture}
+	s.gs = grpc.NewServer(grpc.UnknownServiceHandler(s.handle))
+
+	ropts := reflection.ServerOptions{
+		Services:           mockServices{files},
+		DescriptorResolver: files,
+		ExtensionResolver:  mockExtensions(files),
+	}
+	v1reflectiongrpc.RegisterServerReflectionServer(s.gs, reflection.NewServerV1(ropts))
+	v1alphareflectiongrpc.RegisterServerReflectionServer(s.gs, reflection.NewServer(ropts))
+	return s
+}
+
+// serve listens on addr and serves in the background, calling done with the
+// error the server stopped with
+func (s *mockServer) serve(addr string, done func(error)) error {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	s.lis = lis
+	go func() {
+		done(s.gs.Serve(lis))
+	}()
+	return nil
+}
+
+func (s *mockServer) stop() {
+	s.gs.Stop()
+}
+
+func (s *mockServer) status() *mockStatus {
+	st := &mockStatus{Addr: s.lis.Addr().String()}
+	for name := range (mockServices{s.files}).GetServiceInfo() {
+		st.Services = append(st.Services, name)
+	}
+	sort.Strings(st.Services)
+	return st
+}
+
+func (s *mockServer) handle(_ interface{}, stream grpc.ServerStream) error {
+	method, ok := grpc.MethodFromServerStream(stream)
+	if !ok {
+		return status.Error(codes.Internal, "mock: no method in stream")
+	}
+	name := strings.Replace(strings.TrimPrefix(method, "/"), "/", ".", 1)
+	desc, err := s.files.FindDescriptorByName(protoreflect.FullName(name))
+	if err != nil {
+		return status.Errorf(codes.Unimplemented, "mock: unknown method %s", method)
+	}
+	md, ok := desc.(protoreflect.MethodDescriptor)
+	if !ok {
+		return status.Errorf(codes.Unimplemented, "mock: unknown method %s", method)
+	}
+
+	f, err := s.fixture(method)
+	if err != nil {
+		return status.Errorf(codes.Internal, "mock: failed to load fixture: %v", err)
+	}
+	if f == nil {
+		// Without a fixture every method answers with an empty message
+		f = &mockFixture{Method: method, Responses: []mockResponse{{Body: "{}"}}}
+	}
+
+	if len(f.Header) > 0 {
+		stream.SetHeader(f.Header.md())
+	}
+	if len(f.Trailer) > 0 {
+		stream.SetTrailer(f.Trailer.md())
+	}
+
+	data := mockCallData{Metadata: make(map[string]string)}
+	if in, ok := metadata.FromIncomingContext(stream.Context()); ok {
+		for k, v := range in {
+			if len(v) > 0 {
+				data.Metadata[k] = v[0]
+			}
+		}
+	}
+
+	r := mockCall{f, md, stream, data}
+	if md.IsStreamingClient() && md.IsStreamingServer() {
+		return r.bidi()
+	}
+	return r.run()
+}
+
+// mockCall is a single call to the mock server
+type mockCall struct {
+	f      *mockFixture
+	md     protoreflect.MethodDescriptor
+	stream grpc.ServerStream
+	data   mockCallData
+}
+
+func (r *mockCall) recv() (bool, error) {
+	req := dynamicpb.NewMessage(r.md.Input())
+	if err := r.stream.RecvMsg(req); err != nil {
+		if err == io.EOF {
+			return false, nil
+		}
+		return false, err
+	}
+	b, err := protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}.Marshal(req)
+	if err != nil {
+		return false, status.Errorf(codes.Internal, "mock: failed to marshal request: %v", err)
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return false, status.Errorf(codes.Internal, "mock: failed to decode request: %v", err)
+	}
+	r.data.Request = m
+	r.data.Requests = append(r.data.Requests, m)
+	return true, nil
+}
+
+func (r *mockCall) sleep(s float64) error {
+	if s <= 0 {
+		return nil
+	}
+	t := time.NewTimer(seconds(s))
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-r.stream.Context().Done():
+		return status.FromContextError(r.stream.Context().Err()).Err()
+	}
+}
+
+// send renders and sends the responses in order
+func (r *mockCall) send(resps []mockResponse) error {
+	for i, resp := range resps {
+		if err := r.sleep(resp.Delay); err != nil {
+			return err
+		}
+		r.data.Index = i
+		m, err := r.message(resp.Body)
+		if err != nil {
+			return err
+		}
+		if err := r.stream.SendMsg(m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *mockCall) message(body string) (proto.Message, error) {
+	b, err := renderMockBody(body, r.data)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "mock: %v", err)
+	}
+	m := dynamicpb.NewMessage(r.md.Output())
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(b, m); err != nil {
+		return nil, status.Errorf(codes.Internal, "mock: failed to unmarshal response: %v", err)
+	}
+	return m, nil
+}
+
+func (r *mockCall) end() error {
+	if r.f.Code != 0 {
+		return status.Error(codes.Code(r.f.Code), r.f.Message)
+	}
+	return nil
+}
+
+// run answers unary, client and server streaming calls after every request
+// has been received
+func (r *mockCall) run() error {
+	for {
+		more, err := r.recv()
+		if err != nil {
+			return err
+		}
+		if !more || !r.md.IsStreamingClient() {
+			break
+		}
+	}
+	if err := r.sleep(r.f.Latency); err != nil {
+		return err
+	}
+	resps := r.f.Responses
+	if !r.md.IsStreamingServer() {
+		if r.f.Code != 0 {
+			return r.end()
+		}
+		if len(resps) == 0 {
+			resps = []mockResponse{{Body: "{}"}}
+		}
+		if len(resps) > 1 {
+			resps = resps[:1]
+		}
+	}
+	if err := r.send(resps); err != nil {
+		return err
+	}
+	return r.end()
+}
+
+// bidi answers each request with the responses of the fixture
+func (r *mockCall) bidi() error {
+	if err := r.sleep(r.f.Latency); err != nil {
+		return err
+	}
+	for {
+		more, err := r.recv()
+		if err != nil {
+			return err
+		}
+		if !more {
+			return r.end()
+		}
+		if err := r.send(r.f.Responses); err != nil {
+			return err
+		}
+	}
+}
+
+func (hs headers) md() metadata.MD {
+	md := metadata.MD{}
+	for _, h := range hs {
+		if h.Key == "" {
+			continue
+		}
+		md.Append(h.Key, h.Val)
+	}
+	return md
+}
+
+var mockFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+	"now": func() string {
+		return time.Now().Format(time.RFC3339Nano)
+	},
+	"uuid": func() string {
+		return uuid.Must(uuid.NewV4()).String()
+	},
+}
+
+func parseMockBody(body string) (*template.Template, error) {
+	return template.New("body").Funcs(mockFuncs).Option("missingkey=zero").Parse(body)
+}
+
+func renderMockBody(body string, data mockCallData) ([]byte, error) {
+	if !strings.Contains(body, "{{") {
+		return []byte(body), nil
+	}
+	tmpl, err := parseMockBody(body)
+	if err != nil {
+		return nil, fmt.Errorf("invalid response template: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to execute response template: %v", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// historyFixture builds a fixture that answers like the recorded call. The
+// delays of the responses are those of the recording. Only the responses to
+// the first request of a bidirectional stream are used, as the mock server
+// sends them for every request.
+func historyFixture(e *historyEntry) mockFixture {
+	f := mockFixture{
+		Method:  e.Method,
+		Code:    uint32(e.StatusCode),
+		Message: e.StatusMessage,
+		Header:  userHeaders(e.ResponseHeader),
+		Trailer: userHeaders(e.Trailer),
+	}
+	var last float64 // offset of the previous message
+	requests := 0
+	for _, m := range e.Messages {
+		if m.Outbound {
+			requests++
+			if e.ClientStream && e.ServerStream && requests > 1 {
+				break
+			}
+			last = m.Offset
+			continue
+		}
+		delay := (m.Offset - last) / 1000
+		if delay < 0 {
+			delay = 0
+		}
+		last = m.Offset
+		if len(f.Responses) == 0 {
+			f.Latency = delay
+			delay = 0
+		}
+		f.Responses = append(f.Responses, mockResponse{Body: m.JSON, Delay: delay})
+	}
+	return f
+}
+
+// mockFixture returns the saved fixture for the method of the address, or nil
+// if there is none
+func (a *api) mockFixture(addr, method string) (*mockFixture, error) {
+	val, err := a.store.get(mockKey(addr, method))
+	if err != nil {
+		if errors.Is(err, errKeyNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var f mockFixture
+	if err := gob.NewDecoder(bytes.NewBuffer(val)).Decode(&f); err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+func (a *api) saveMockFixture(addr string, f mockFixture) error {
+	if !strings.HasPrefix(f.Method, "/") || strings.Count(f.Method, "/") != 2 {
+		return fmt.Errorf("invalid method name: %q", f.Method)
+	}
+	if f.Latency < 0 {
+		return errors.New("latency must not be negative")
+	}
+	for i, r := range f.Responses {
+		if r.Delay < 0 {
+			return fmt.Errorf("response %d: delay must not be negative", i+1)
+		}
+		if _, err := parseMockBody(r.Body); err != nil {
+			return fmt.Errorf("response %d: invalid template: %v", i+1, err)
+		}
+	}
+	var val bytes.Buffer
+	if err := gob.NewEncoder(&val).Encode(f); err != nil {
+		return err
+	}
+	return a.store.set(mockKey(addr, f.Method), val.Bytes())
+}
+
+// StartMockServer serves the services of the current workspace's proto files
+// on the address in the options, answering each method with its fixture. It
+// replaces any mock server that is already running.
+func (a *api) StartMockServer(rawOpts interface{}) (st *mockStatus, rerr error) {
+	defer func() {
+		if rerr != nil {
+			const errTitle = "Unable to start mock server"
+			runtime.LogError(a.ctx, rerr.Error())
+			a.emitError(errTitle, rerr.Error())
+		}
+	}()
+
+	var opts mockOptions
+	if err := mapstructure.Decode(rawOpts, &opts); err != nil {
+		return nil, fmt.Errorf("failed to decode mock server options: %v", err)
+	}
+	if opts.Addr == "" {
+		opts.Addr = defaultMockAddr
+	}
+
+	conn := a.current()
+	if conn == nil {
+		return nil, errNoConn
+	}
+	files := conn.files()
+	if files == nil {
+		return nil, errors.New("no proto files loaded")
+	}
+
+	a.StopMockServer()
+
+	addr := conn.opts.Addr
+	s := newMockServer(files, func(method string) (*mockFixture, error) {
+		return a.mockFixture(addr, method)
+	})
+	err := s.serve(opts.Addr, func(err error) {
+		if err != nil {
+			const errTitle = "Mock server stopped"
+			runtime.LogError(a.ctx, err.Error())
+			a.emitError(errTitle, err.Error())
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %v", opts.Addr, err)
+	}
+
+	a.mu.Lock()
+	a.mock = s
+	a.mu.Unlock()
+	return s.status(), nil
+}
+
+// StopMockServer stops the mock server if it is running
+func (a *api) StopMockServer() {
+	a.mu.Lock()
+	s := a.mock
+	a.mock = nil
+	a.mu.Unlock()
+	if s != nil {
+		s.stop()
+	}
+}
+
+// GetMockServer returns the address and services of the running mock server,
+// or nil if it isn't running
+func (a *api) GetMockServer() *mockStatus {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.mock == nil {
+		return nil
+	}
+	return a.mock.status()
+}
+
+// SaveMockFixture stores how the mock server answers a method of the current
+// workspace, replacing any previous fixture for it. A running mock server
+// uses it from the next call.
+func (a *api) SaveMockFixture(rawFixture interface{}) error {
+	var f mockFixture
+	if err := mapstructure.Decode(rawFixture, &f); err != nil {
+		return fmt.Errorf("failed to decode mock fixture: %v", err)
+	}
+	opts, err := a.GetWorkspaceOptions()
+	if err != nil {
+		return err
+	}
+	return a.saveMockFixture(opts.Addr, f)
+}
+
+// GetMockFixture returns the fixture of the method of the current workspace,
+// or nil if there is none
+func (a *api) GetMockFixture(method string) (*mockFixture, error) {
+	opts, err := a.GetWorkspaceOptions()
+	if err != nil {
+		return nil, err
+	}
+	return a.mockFixture(opts.Addr, method)
+}
+
+// ListMockFixtures returns every saved fixture of the current workspace
+func (a *api) ListMockFixtures() ([]mockFixture, error) {
+	opts, err := a.GetWorkspaceOptions()
+	if err != nil {
+		return nil, err
+	}
+	items, err := a.store.list([]byte(mockKeyPrefix + hash(opts.Addr) + "_"))
+	if err != nil {
+		return nil, err
+	}
+	var fixtures []mockFixture
+	for _, val := range items {
+		var f mockFixture
+		if err := gob.NewDecoder(bytes.NewBuffer(val)).Decode(&f); err != nil {
+			return fixtures, err
+		}
+		fixtures = append(fixtures, f)
+	}
+	return fixtures, nil
+}
+
+// PromoteHistoryEntry saves a fixture built from a recorded call of the
+// current workspace, replacing any fixture for its method
+func (a *api) PromoteHistoryEntry(id string) (*mockFixture, error) {
+	opts, err := a.GetWorkspaceOptions()
+	if err != nil {
+		return nil, err
+	}
+	e, err := a.getHistoryEntry(opts.Addr, id)
+	if err != nil {
+		return nil, err
+	}
+	f := historyFixture(e)
+	if err := a.saveMockFixture(opts.Addr, f); err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+// DeleteMockFixture removes the fixture of the method of the current
+// workspace, so the mock server answers it with an empty message
+func (a *api) DeleteMockFixture(method string) error {
+	opts, err := a.GetWorkspaceOptions()
+	if err != nil {
+		return err
+	}
+	return a.store.del(mockKey(opts.Addr, method))
+}
@@ -14,6 +14,23 @@ type workspaceState struct {
 type protos struct {
 	Files []string `json:"files"`
 	Roots []string `json:"roots"`
+	// Protosets are compiled FileDescriptorSet files, used instead of Files
+	Protosets []string `json:"protosets"`
+}
+
+// keepaliveOptions only apply with a Time, as pings are off without one
+type keepaliveOptions struct {
+	Time                float64 `json:"time"`
+	Timeout             float64 `json:"timeout"`
+	PermitWithoutStream bool    `json:"permit_without_stream" mapstructure:"permit_without_stream"`
+}
+
+type backoffOptions struct {
+	BaseDelay         float64 `json:"base_delay" mapstructure:"base_delay"`
+	Multiplier        float64 `json:"multiplier"`
+	Jitter            float64 `json:"jitter"`
+	MaxDelay          float64 `json:"max_delay" mapstructure:"max_delay"`
+	MinConnectTimeout float64 `json:"min_connect_timeout" mapstructure:"min_connect_timeout"`
 }
 
 type options struct {
@@ -22,45 +39,92 @@ type options struct {
 	Reflect bool   `json:"reflect"`
 	Protos  protos `json:"protos"`
 
+	// Transport is one of grpc (default), grpc-web, grpc-web-text or connect
+	Transport string `json:"transport"`
+	// Codec is the message encoding for the connect transport: proto or json
+	Codec string `json:"codec"`
+
 	Insecure   bool   `json:"insecure"`
 	Plaintext  bool   `json:"plaintext"`
 	Rootca     string `json:"rootca"`
 	Clientcert string `json:"clientcert"`
 	Clientkey  string `json:"clientkey"`
+	// ServerName overrides the name the server certificate is verified for
+	ServerName string `json:"server_name" mapstructure:"server_name"`
+	// Authority overrides the :authority pseudo-header of calls
+	Authority string `json:"authority"`
+
+	ServiceConfig string `json:"service_config" mapstructure:"service_config"`
+
+	// Durations are in seconds; zero values use the grpc defaults
+	ConnectTimeout float64          `json:"connect_timeout" mapstructure:"connect_timeout"`
+	Keepalive      keepaliveOptions `json:"keepalive"`
+	Backoff        backoffOptions   `json:"backoff"`
+
+	// Timeout is the deadline of each call in seconds, or none if zero
+	Timeout float64 `json:"timeout"`
+
+	// InputFormat is the format of request bodies: json (default), jsonc,
+	// yaml, text, or the binary format as base64 or hex
+	InputFormat string `json:"input_format" mapstructure:"input_format"`
+	// StrictFields rejects requests with unknown fields rather than
+	// dropping them
+	StrictFields bool `json:"strict_fields" mapstructure:"strict_fields"`
+
+	// SkipValidation sends requests that break the buf.validate or
+	// protoc-gen-validate rules of their fields, to test how the server
+	// handles them
+	SkipValidation bool `json:"skip_validation" mapstructure:"skip_validation"`
+}
+
+// comments are the source comments of a descriptor, which are only known
+// for proto files and protosets compiled with source info
+type comments struct {
+	Leading  string `json:"leading"`
+	Trailing string `json:"trailing"`
 }
 
 type methodSelect struct {
-	FullName     string `json:"full_name"`
-	Name         string `json:"name"`
-	ClientStream bool   `json:"client_stream"`
-	ServerStream bool   `json:"server_stream"`
+	FullName     string   `json:"full_name"`
+	Name         string   `json:"name"`
+	ClientStream bool     `json:"client_stream"`
+	ServerStream bool     `json:"server_stream"`
+	Comments     comments `json:"comments"`
+	Deprecated   bool     `json:"deprecated"`
 }
 
 type methodsSelect []methodSelect
 
 type serviceSelect struct {
-	FullName string        `json:"full_name"`
-	Methods  methodsSelect `json:"methods"`
+	FullName   string        `json:"full_name"`
+	Methods    methodsSelect `json:"methods"`
+	Comments   comments      `json:"comments"`
+	Deprecated bool          `json:"deprecated"`
 }
 
 type servicesSelect []serviceSelect
 
 type fieldDesc struct {
-	Name     string       `json:"name"`
-	FullName string       `json:"full_name"`
-	Kind     string       `json:"kind"`
-	Repeated bool         `json:"repeated"`
-	MapKey   *fieldDesc   `json:"map_key"`
-	MapValue *fieldDesc   `json:"map_value"`
-	Oneof    []fieldDesc  `json:"oneof"`
-	Enum     []string     `json:"enum"`
-	Message  *messageDesc `json:"message"`
+	Name       string       `json:"name"`
+	FullName   string       `json:"full_name"`
+	Kind       string       `json:"kind"`
+	Repeated   bool         `json:"repeated"`
+	MapKey     *fieldDesc   `json:"map_key"`
+	MapValue   *fieldDesc   `json:"map_value"`
+	Oneof      []fieldDesc  `json:"oneof"`
+	Enum       []string     `json:"enum"`
+	Message    *messageDesc `json:"message"`
+	JSONName   string       `json:"json_name"`
+	Comments   comments     `json:"comments"`
+	Deprecated bool         `json:"deprecated"`
 }
 
 type messageDesc struct {
-	Name     string      `json:"name"`
-	FullName string      `json:"full_name"`
-	Fields   []fieldDesc `json:"fields"`
+	Name       string      `json:"name"`
+	FullName   string      `json:"full_name"`
+	Fields     []fieldDesc `json:"fields"`
+	Comments   comments    `json:"comments"`
+	Deprecated bool        `json:"deprecated"`
 }
 
 type methodInput struct {
@@ -76,14 +140,19 @@ type header struct {
 type headers []header
 
 type rpcStart struct {
-	ClientStream bool `json:"client_stream"`
-	ServerStream bool `json:"server_stream"`
+	ClientStream bool   `json:"client_stream"`
+	ServerStream bool   `json:"server_stream"`
+	CallID       string `json:"call_id"`
 }
 
 type rpcEnd struct {
 	Status     string `json:"status"`
 	StatusCode int32  `json:"status_code"`
 	Duration   string `json:"duration"`
+
+	Attempts           int    `json:"attempts"`
+	TransparentRetries int    `json:"transparent_retries"`
+	CallID             string `json:"call_id"`
 }
 
 type errorMsg struct {
@@ -91,39 +160,52 @@ type errorMsg struct {
 	Message string `json:"msg"`
 }
 
+type rpcStatBegin struct {
+	*stats.Begin
+	Attempt int
+	CallID  string
+}
+
 type rpcStatOutHeader struct {
 	*stats.OutHeader
 	Header string
+	CallID string
 }
 
 type rpcStatOutPayload struct {
 	*stats.OutPayload
-	Data string
+	Data   string
+	CallID string
 }
 
 type rpcStatOutTrailer struct {
 	*stats.OutTrailer
 	Trailer string
+	CallID  string
 }
 
 type rpcStatInHeader struct {
 	*stats.InHeader
 	Header string
+	CallID string
 }
 
 type rpcStatInPayload struct {
 	*stats.InPayload
-	Data string
+	Data   string
+	CallID string
 }
 
 type rpcStatInTrailer struct {
 	*stats.InTrailer
 	Trailer string
+	CallID  string
 }
 
 type rpcStatEnd struct {
 	*stats.End
-	Error string
+	Error  string
+	CallID string
 }
 
 type releaseInfo struct {
@@ -133,5 +215,23 @@ type releaseInfo struct {
 }
 
 type commands struct {
-	Grpcurl string `json:"grpcurl"`
+	Grpcurl  string        `json:"grpcurl"`
+	Curl     string        `json:"curl"`
+	Snippets []codeSnippet `json:"snippets"`
+}
+
+// importedCommand is the workspace and call of an imported command
+type importedCommand struct {
+	Workspace       options `json:"workspace"`
+	Method          string  `json:"method"`
+	Data            string  `json:"data"`
+	Metadata        headers `json:"metadata"`
+	ReflectMetadata headers `json:"reflect_metadata"`
+
+	// payload is a binary request, converted to Data once the method's
+	// proto files are loaded
+	payload []byte
+	// template generates an example request as Data once the method's proto
+	// files are loaded
+	template bool
 }
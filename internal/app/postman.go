@@ -0,0 +1,611 @@
+package app
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	postmanSchema     = "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+	postmanTLSScheme  = "grpcs://"
+	postmanGrpcScheme = "grpc://"
+)
+
+// postmanCollection is the subset of a Postman collection (v2.0 or v2.1)
+// that holds gRPC requests
+type postmanCollection struct {
+	Info     postmanInfo       `json:"info"`
+	Item     []postmanItem     `json:"item"`
+	Variable []postmanVariable `json:"variable,omitempty"`
+	Auth     *postmanAuth      `json:"auth,omitempty"`
+	Event    []json.RawMessage `json:"event,omitempty"`
+}
+
+type postmanInfo struct {
+	ID          string `json:"_postman_id,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Schema      string `json:"schema"`
+}
+
+// postmanItem is either a folder of items or a single request
+type postmanItem struct {
+	Name    string            `json:"name"`
+	Item    []postmanItem     `json:"item,omitempty"`
+	Request json.RawMessage   `json:"request,omitempty"`
+	Auth    *postmanAuth      `json:"auth,omitempty"`
+	Event   []json.RawMessage `json:"event,omitempty"`
+}
+
+type postmanVariable struct {
+	Key      string `json:"key"`
+	Value    string `json:"value"`
+	Disabled bool   `json:"disabled,omitempty"`
+}
+
+type postmanKeyValue struct {
+	Key      string `json:"key"`
+	Value    string `json:"value"`
+	Disabled bool   `json:"disabled,omitempty"`
+}
+
+type postmanAuth struct {
+	Type   string            `json:"type"`
+	Bearer []postmanKeyValue `json:"bearer,omitempty"`
+	Basic  []postmanKeyValue `json:"basic,omitempty"`
+	APIKey []postmanKeyValue `json:"apikey,omitempty"`
+}
+
+type postmanMessage struct {
+	Content string `json:"content"`
+}
+
+type postmanSettings struct {
+	EnableServerCertificateVerification *bool `json:"enableServerCertificateVerification,omitempty"`
+}
+
+// postmanGrpcRequest is the request of a gRPC item. HTTP requests have a
+// method (the HTTP verb) rather than a methodPath.
+type postmanGrpcRequest struct {
+	URL        json.RawMessage   `json:"url"`
+	MethodPath string            `json:"methodPath"`
+	Metadata   []postmanKeyValue `json:"metadata"`
+	Message    json.RawMessage   `json:"message"`
+	Auth       *postmanAuth      `json:"auth,omitempty"`
+	Settings   *postmanSettings  `json:"settings,omitempty"`
+}
+
+// postmanImport is the outcome of importing a collection. Unsupported lists
+// the parts of the collection that were skipped or couldn't be represented.
+// postmanImport is the outcome of an import. Replaced lists the stored
+// metadata and requests of existing workspaces that the collection replaced.
+type postmanImport struct {
+	Name        string    `json:"name"`
+	Workspaces  []options `json:"workspaces"`
+	Requests    int       `json:"requests"`
+	Unsupported []string  `json:"unsupported"`
+	Replaced    []string  `json:"replaced"`
+}
+
+type postmanExport struct {
+	Collection  string   `json:"collection"`
+	Unsupported []string `json:"unsupported"`
+}
+
+var postmanVariableRe = regexp.MustCompile(`{{\s*([^{}]+?)\s*}}`)
+
+// postmanImporter walks the items of a collection, collecting the requests
+// by address
+type postmanImporter struct {
+	vars        map[string]string
+	unsupported []string
+	unresolved  map[string]bool
+
+	addrs     []string
+	plaintext map[string]bool
+	insecure  map[string]bool
+	metadata  map[string]headers
+	messages  map[string]map[string]string
+	order     map[string][]string
+	requests  int
+}
+
+func newPostmanImporter(c *postmanCollection) *postmanImporter {
+	p := &postmanImporter{
+		vars:       make(map[string]string),
+		unresolved: make(map[string]bool),
+		plaintext:  make(map[string]bool),
+		insecure:   make(map[string]bool),
+		metadata:   make(map[string]headers),
+		messages:   make(map[string]map[string]string),
+		order:      make(map[string][]string),
+	}
+	for _, v := range c.Variable {
+		if !v.Disabled {
+			p.vars[v.Key] = v.Value
+		}
+	}
+	return p
+}
+
+func (p *postmanImporter) report(format string, args ...interface{}) {
+	p.unsupported = append(p.unsupported, fmt.Sprintf(format, args...))
+}
+
+// expand replaces the collection variables in s. Variables without a value,
+// including Postman's dynamic variables such as {{$guid}}, are left as they
+// are and reported once.
+func (p *postmanImporter) expand(s string) string {
+	return postmanVariableRe.ReplaceAllStringFunc(s, func(m string) string {
+		name := postmanVariableRe.FindStringSubmatch(m)[1]
+		if v, ok := p.vars[name]; ok {
+			return v
+		}
+		if !p.unresolved[name] {
+			p.unresolved[name] = true
+			p.report("variable {{%s}} has no value in the collection and was left as is", name)
+		}
+		return m
+	})
+}
+
+// authHeader returns the metadata of the auth, which Postman sends in
+// addition to the request metadata
+func (p *postmanImporter) authHeader(auth *postmanAuth, item string) (header, bool) {
+	if auth == nil {
+		return header{}, false
+	}
+	get := func(kvs []postmanKeyValue, key string) string {
+		for _, kv := range kvs {
+			if kv.Key == key {
+				return p.expand(kv.Value)
+			}
+		}
+		return ""
+	}
+	switch auth.Type {
+	case "", "noauth", "inherit":
+		return header{}, false
+	case "bearer":
+		return header{Key: "authorization", Val: "Bearer " + get(auth.Bearer, "token")}, true
+	case "basic":
+		creds := get(auth.Basic, "username") + ":" + get(auth.Basic, "password")
+		return header{Key: "authorization", Val: "Basic " + base64.StdEncoding.EncodeToString([]byte(creds))}, true
+	case "apikey":
+		if in := get(auth.APIKey, "in"); in != "" && in != "header" {
+			p.report("%s: API key auth in the %s is not supported", item, in)
+			return header{}, false
+		}
+		return header{Key: strings.ToLower(get(auth.APIKey, "key")), Val: get(auth.APIKey, "value")}, true
+	default:
+		p.report("%s: %s auth is not supported", item, auth.Type)
+		return header{}, false
+	}
+}
+
+// walk imports the requests of the items, with auth inherited from the
+// enclosing folders
+func (p *postmanImporter) walk(items []postmanItem, path string, auth *postmanAuth) {
+	for _, it := range items {
+		name := it.Name
+		if path != "" {
+			name = path + "/" + it.Name
+		}
+		if len(it.Event) > 0 {
+			p.report("%s: pre-request and test scripts are not supported", name)
+		}
+		itemAuth := auth
+		if it.Auth != nil && it.Auth.Type != "inherit" {
+			itemAuth = it.Auth
+		}
+		if it.Request == nil {
+			p.walk(it.Item, name, itemAuth)
+			continue
+		}
+		if err := p.request(it.Request, name, itemAuth); err != nil {
+			p.report("%s: %v", name, err)
+		}
+	}
+}
+
+func (p *postmanImporter) request(raw json.RawMessage, name string, auth *postmanAuth) error {
+	var req postmanGrpcRequest
+	if err := json.Unmarshal(raw, &req); err != nil {
+		return errors.New("only gRPC requests are supported")
+	}
+	if req.MethodPath == "" {
+		return errors.New("only gRPC requests are supported")
+	}
+
+	var url string
+	if err := json.Unmarshal(req.URL, &url); err != nil {
+		return fmt.Errorf("invalid url: %v", err)
+	}
+	addr, plaintext := postmanAddr(p.expand(url))
+	if addr == "" {
+		return errors.New("the request has no url")
+	}
+
+	method := p.expand(req.MethodPath)
+	if !strings.HasPrefix(method, "/") {
+		method = "/" + method
+	}
+	if strings.Count(method, "/") != 2 {
+		return fmt.Errorf("invalid method path %q", req.MethodPath)
+	}
+
+	body, err := postmanMessageContent(req.Message)
+	if err != nil {
+		return err
+	}
+	body = p.expand(body)
+	if body == "" {
+		body = "{}"
+	}
+	if !json.Valid([]byte(body)) {
+		return errors.New("the message is not valid JSON")
+	}
+
+	var hs headers
+	for _, kv := range req.Metadata {
+		if kv.Disabled || kv.Key == "" {
+			continue
+		}
+		hs = append(hs, header{Key: strings.ToLower(p.expand(kv.Key)), Val: p.expand(kv.Value)})
+	}
+	if req.Auth != nil && req.Auth.Type != "inherit" {
+		auth = req.Auth
+	}
+	if h, ok := p.authHeader(auth, name); ok {
+		hs = append(hs, h)
+	}
+
+	if _, ok := p.messages[addr]; !ok {
+		p.addrs = append(p.addrs, addr)
+		p.messages[addr] = make(map[string]string)
+		p.plaintext[addr] = plaintext
+		p.metadata[addr] = hs
+		if req.Settings != nil && req.Settings.EnableServerCertificateVerification != nil {
+			p.insecure[addr] = !*req.Settings.EnableServerCertificateVerification
+		}
+	} else if !equalHeaders(p.metadata[addr], hs) {
+		// Metadata is kept per workspace rather than per request
+		p.report("%s: metadata differs from the other requests to %s and was not imported", name, addr)
+	}
+
+	if _, ok := p.messages[addr][method]; ok {
+		// Only one message is kept per method
+		p.report("%s: another request to %s was already imported for %s", name, method, addr)
+		return nil
+	}
+	p.messages[addr][method] = body
+	p.order[addr] = append(p.order[addr], method)
+	p.requests++
+	return nil
+}
+
+func equalHeaders(a, b headers) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// postmanAddr returns the address of a Postman gRPC url, which has a
+// grpcs:// scheme when TLS is enabled
+func postmanAddr(url string) (addr string, plaintext bool) {
+	url = strings.TrimSpace(url)
+	switch {
+	case strings.HasPrefix(url, postmanTLSScheme):
+		return strings.TrimSuffix(strings.TrimPrefix(url, postmanTLSScheme), "/"), false
+	case strings.HasPrefix(url, postmanGrpcScheme):
+		return strings.TrimSuffix(strings.TrimPrefix(url, postmanGrpcScheme), "/"), true
+	default:
+		return strings.TrimSuffix(url, "/"), true
+	}
+}
+
+// postmanMessageContent returns the JSON body of a message, which is either
+// a string or an object with the content
+func postmanMessageContent(raw json.RawMessage) (string, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return "", nil
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s, nil
+	}
+	var m postmanMessage
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return "", fmt.Errorf("invalid message: %v", err)
+	}
+	return m.Content, nil
+}
+
+func parsePostmanCollection(collection string) (*postmanCollection, error) {
+	var c postmanCollection
+	if err := json.Unmarshal([]byte(collection), &c); err != nil {
+		return nil, fmt.Errorf("invalid collection JSON: %v", err)
+	}
+	if !strings.Contains(c.Info.Schema, "schema.getpostman.com/json/collection/v2") {
+		return nil, errors.New("not a Postman v2 collection")
+	}
+	return &c, nil
+}
+
+// ImportPostman imports the gRPC requests of a Postman collection. Each
+// address becomes a workspace, using the existing grpc workspace with that
+// address if there is one, and each request's message is stored for its
+// method along with the metadata of the workspace. What it replaces of an
+// existing workspace is reported.
+func (a *api) ImportPostman(collection string) (res *postmanImport, rerr error) {
+	defer func() {
+		if rerr != nil {
+			const errTitle = "Failed to import Postman collection"
+			runtime.LogError(a.ctx, rerr.Error())
+			runtime.EventsEmit(a.ctx, eventError, errorMsg{errTitle, rerr.Error()})
+		}
+	}()
+
+	c, err := parsePostmanCollection(collection)
+	if err != nil {
+		return nil, err
+	}
+
+	p := newPostmanImporter(c)
+	if len(c.Event) > 0 {
+		p.report("collection: pre-request and test scripts are not supported")
+	}
+	p.walk(c.Item, "", c.Auth)
+	if p.requests == 0 {
+		return nil, fmt.Errorf("the collection has no gRPC requests: %s", strings.Join(p.unsupported, "; "))
+	}
+
+	workspaces, err := a.ListWorkspaces()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workspaces: %v", err)
+	}
+
+	res = &postmanImport{Name: c.Info.Name, Requests: p.requests, Unsupported: p.unsupported}
+	for _, addr := range p.addrs {
+		var opts *options
+		for i, ws := range workspaces {
+			if ws.Addr == addr && !isHTTPTransport(ws.Transport) {
+				opts = &workspaces[i]
+				break
+			}
+		}
+		if opts == nil {
+			// Postman collections don't carry the proto definitions
+			opts = &options{
+				ID:        workspacePrefix + uuid.Must(uuid.NewV4()).String(),
+				Addr:      addr,
+				Reflect:   true,
+				Plaintext: p.plaintext[addr],
+				Insecure:  !p.plaintext[addr] && p.insecure[addr],
+			}
+			a.setWorkspaceOptions(*opts)
+		}
+		res.Workspaces = append(res.Workspaces, *opts)
+		a.importPostmanWorkspace(p, *opts, res)
+	}
+
+	runtime.LogInfo(a.ctx, fmt.Sprintf("imported %d requests from Postman collection %q", p.requests, c.Info.Name))
+	return res, nil
+}
+
+// importPostmanWorkspace stores the metadata and messages of the address of
+// the workspace. The metadata is merged with what is stored, and messages are
+// converted to the workspace's request format, which needs the proto files
+// for the text and binary formats.
+func (a *api) importPostmanWorkspace(p *postmanImporter, opts options, res *postmanImport) {
+	addr := opts.Addr
+	stored, err := a.GetMetadata(addr)
+	if err != nil && err != errKeyNotFound {
+		res.Unsupported = append(res.Unsupported, fmt.Sprintf("%s: metadata was not imported: %v", addr, err))
+	} else {
+		md, replaced := mergeMetadata(stored, p.metadata[addr])
+		for _, key := range replaced {
+			res.Replaced = append(res.Replaced, fmt.Sprintf("%s: metadata %s", addr, key))
+		}
+		a.setMetadata(metadataKeyPrefix+hash(addr), md)
+	}
+
+	format := opts.requestFormat()
+	var conn *connection
+	if !format.isJSON() && format.format != formatJSONC && format.format != formatYAML {
+		if conn, err = a.openWorkspace(opts.ID); err != nil {
+			res.Unsupported = append(res.Unsupported, fmt.Sprintf("%s: requests were not imported as %s: %v", addr, format.format, err))
+			return
+		}
+	}
+	for _, method := range p.order[addr] {
+		var input protoreflect.MessageDescriptor
+		if conn != nil {
+			md, err := conn.methodDesc(method)
+			if err != nil {
+				res.Unsupported = append(res.Unsupported, fmt.Sprintf("%s%s: request was not imported: %v", addr, method, err))
+				continue
+			}
+			input = md.Input()
+		}
+		body, err := format.fromJSON(input, p.messages[addr][method])
+		if err != nil {
+			res.Unsupported = append(res.Unsupported, fmt.Sprintf("%s%s: request was not imported as %s: %v", addr, method, format.format, err))
+			continue
+		}
+		if prev, err := a.store.get([]byte(messageKeyPrefix + hash(addr, method))); err == nil && len(prev) > 0 && string(prev) != body {
+			res.Replaced = append(res.Replaced, fmt.Sprintf("%s%s: request", addr, method))
+		}
+		a.setMessage(addr, method, []byte(body))
+	}
+}
+
+// mergeMetadata adds the imported headers to the stored ones, replacing the
+// stored values of the keys they set, and returns the keys whose values were
+// replaced
+func mergeMetadata(stored, imported headers) (headers, []string) {
+	set := make(map[string]bool)
+	for _, h := range imported {
+		set[strings.ToLower(h.Key)] = true
+	}
+	var merged headers
+	var replaced []string
+	reported := make(map[string]bool)
+	for _, h := range stored {
+		key := strings.ToLower(h.Key)
+		if !set[key] {
+			merged = append(merged, h)
+			continue
+		}
+		if !reported[key] && !slices.Contains(imported, h) {
+			reported[key] = true
+			replaced = append(replaced, h.Key)
+		}
+	}
+	return append(merged, imported...), replaced
+}
+
+// ExportPostman exports the stored messages of the workspace's methods as a
+// Postman collection, with a folder per service. The workspace is connected
+// to list its methods.
+func (a *api) ExportPostman(workspaceID string) (res *postmanExport, rerr error) {
+	defer func() {
+		if rerr != nil {
+			const errTitle = "Failed to export Postman collection"
+			runtime.LogError(a.ctx, rerr.Error())
+			runtime.EventsEmit(a.ctx, eventError, errorMsg{errTitle, rerr.Error()})
+		}
+	}()
+
+	if workspaceID == "" {
+		workspaceID = a.currentID()
+	}
+	conn, err := a.openWorkspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	opts := conn.opts
+
+	res = &postmanExport{}
+	report := func(format string, args ...interface{}) {
+		res.Unsupported = append(res.Unsupported, fmt.Sprintf(format, args...))
+	}
+	if isHTTPTransport(opts.Transport) {
+		report("the %s transport is not supported by Postman, requests use grpc", opts.Transport)
+	}
+	if !opts.Reflect {
+		report("proto files are not exported, import them into Postman as an API definition")
+	}
+	if opts.Rootca != "" || opts.Clientcert != "" {
+		report("certificates are not exported, add them to Postman's certificate settings")
+	}
+	if opts.ServerName != "" || opts.Authority != "" {
+		report("the server name and authority overrides are not exported")
+	}
+	if opts.ServiceConfig != "" {
+		report("the service config is not exported")
+	}
+	if opts.Timeout > 0 {
+		report("the call timeout is not exported")
+	}
+
+	url := opts.Addr
+	if !opts.Plaintext {
+		url = postmanTLSScheme + url
+	}
+	var settings *postmanSettings
+	if !opts.Plaintext && opts.Insecure {
+		verify := false
+		settings = &postmanSettings{EnableServerCertificateVerification: &verify}
+	}
+	urlJSON, _ := json.Marshal(url)
+
+	md, err := a.GetMetadata(opts.Addr)
+	if err != nil && err != errKeyNotFound {
+		return nil, fmt.Errorf("failed to get metadata: %v", err)
+	}
+	metadata := []postmanKeyValue{}
+	for _, h := range md {
+		metadata = append(metadata, postmanKeyValue{Key: h.Key, Value: h.Val})
+	}
+
+	var services []protoreflect.ServiceDescriptor
+	conn.files().RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		for i := 0; i < fd.Services().Len(); i++ {
+			services = append(services, fd.Services().Get(i))
+		}
+		return true
+	})
+	sort.Slice(services, func(i, j int) bool {
+		return services[i].FullName() < services[j].FullName()
+	})
+
+	var folders []postmanItem
+	for _, sd := range services {
+		folder := postmanItem{Name: string(sd.FullName())}
+		for i := 0; i < sd.Methods().Len(); i++ {
+			m := sd.Methods().Get(i)
+			method := fmt.Sprintf("/%s/%s", sd.FullName(), m.Name())
+			body, err := a.store.get([]byte(messageKeyPrefix + hash(opts.Addr, method)))
+			if err != nil {
+				continue
+			}
+			// Postman messages are JSON, whatever the format of the workspace
+			if body, err = opts.requestFormat().requestJSON(m.Input(), body); err != nil {
+				continue
+			}
+			msgJSON, _ := json.Marshal(postmanMessage{Content: string(body)})
+			req, err := json.Marshal(postmanGrpcRequest{
+				URL:        urlJSON,
+				MethodPath: method[1:],
+				Metadata:   metadata,
+				Message:    msgJSON,
+				Settings:   settings,
+			})
+			if err != nil {
+				return nil, err
+			}
+			folder.Item = append(folder.Item, postmanItem{Name: string(m.Name()), Request: req})
+		}
+		if len(folder.Item) > 0 {
+			folders = append(folders, folder)
+		}
+	}
+	if len(folders) == 0 {
+		report("no messages have been sent in the workspace, so the collection is empty")
+	}
+
+	c := postmanCollection{
+		Info: postmanInfo{
+			ID:     uuid.Must(uuid.NewV4()).String(),
+			Name:   opts.Addr,
+			Schema: postmanSchema,
+		},
+		Item: folders,
+	}
+	if c.Item == nil {
+		c.Item = []postmanItem{}
+	}
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode collection: %v", err)
+	}
+	res.Collection = string(b)
+	return res, nil
+}
@@ -3,6 +3,7 @@ package app
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
 	"os/exec"
 
@@ -155,7 +156,8 @@ func protoFilesFromDisk(importPaths, filenames []string) (*protoregistry.Files,
 	defer os.Remove(tempFilePath)
 
 	// Build protoc command with import paths and output
-	args := []string{"--descriptor_set_out=" + tempFilePath, "--include_imports"}
+	// Source info keeps the comments of the proto files for their docs
+	args := []string{"--descriptor_set_out=" + tempFilePath, "--include_imports", "--include_source_info"}
 
 	// Add import paths
 	for _, importPath := range importPaths {
@@ -187,3 +189,27 @@ func protoFilesFromDisk(importPaths, filenames []string) (*protoregistry.Files,
 	return protodesc.NewFiles(fdset)
 }
 
+// protoFilesFromProtosets reads compiled FileDescriptorSet files, such as
+// those written by protoc --descriptor_set_out
+func protoFilesFromProtosets(filenames []string) (*protoregistry.Files, error) {
+	fdset := &descriptorpb.FileDescriptorSet{}
+	seen := make(map[string]struct{})
+	for _, filename := range filenames {
+		b, err := os.ReadFile(filename)
+		if err != nil {
+			return nil, err
+		}
+		var set descriptorpb.FileDescriptorSet
+		if err := proto.Unmarshal(b, &set); err != nil {
+			return nil, fmt.Errorf("app: invalid protoset %s: %v", filename, err)
+		}
+		for _, fd := range set.GetFile() {
+			if _, ok := seen[fd.GetName()]; ok {
+				continue
+			}
+			seen[fd.GetName()] = struct{}{}
+			fdset.File = append(fdset.File, fd)
+		}
+	}
+	return protodesc.NewFiles(fdset)
+}
@@ -0,0 +1,360 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+const defaultProxyAddr = "localhost:5003"
+
+// proxyOptions configure the recording proxy. WorkspaceID is the workspace
+// calls are forwarded to, the current workspace if empty.
+type proxyOptions struct {
+	Addr        string `json:"addr"`
+	WorkspaceID string `mapstructure:"workspace_id" json:"workspace_id"`
+}
+
+type proxyStatus struct {
+	Addr        string `json:"addr"`
+	WorkspaceID string `json:"workspace_id"`
+	Target      string `json:"target"`
+}
+
+// rawFrame is a message that is forwarded without being decoded
+type rawFrame struct {
+	data []byte
+}
+
+// rawCodec passes frames through as they are. It is named proto so the
+// content type of the calls is unchanged.
+type rawCodec struct{}
+
+func (rawCodec) Marshal(v interface{}) ([]byte, error) {
+	f, ok := v.(*rawFrame)
+	if !ok {
+		return nil, fmt.Errorf("proxy: unexpected message type: %T", v)
+	}
+	return f.data, nil
+}
+
+func (rawCodec) Unmarshal(data []byte, v interface{}) error {
+	f, ok := v.(*rawFrame)
+	if !ok {
+		return fmt.Errorf("proxy: unexpected message type: %T", v)
+	}
+	f.data = append([]byte(nil), data...)
+	return nil
+}
+
+func (rawCodec) Name() string {
+	return "proto"
+}
+
+// proxyFrame is a forwarded message, kept until the call ends so it can be
+// decoded for the history
+type proxyFrame struct {
+	outbound bool
+	at       time.Time
+	data     []byte
+}
+
+// proxy forwards every call it receives to the workspace target, recording
+// each one in the history of the workspace
+type proxy struct {
+	*api
+	workspaceID string
+	conn        *connection // of the workspace, for its proto files
+	client      *client     // dedicated, so forwarded calls don't send events
+	gs          *grpc.Server
+	lis         net.Listener
+}
+
+// proxyCall records a single forwarded call
+type proxyCall struct {
+	mu     sync.Mutex
+	begin  time.Time
+	frames []proxyFrame
+}
+
+func (c *proxyCall) add(outbound bool, f *rawFrame) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.frames = append(c.frames, proxyFrame{outbound, time.Now(), f.data})
+}
+
+func newProxy(a *api, id string, conn *connection, c *client) *proxy {
+	p := &proxy{api: a, workspaceID: id, conn: conn, client: c}
+	p.gs = grpc.NewServer(
+		grpc.UnknownServiceHandler(p.handle),
+		grpc.ForceServerCodec(rawCodec{}),
+	)
+	return p
+}
+
+func (p *proxy) serve(addr string) error {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	p.lis = lis
+	go func() {
+		if err := p.gs.Serve(lis); err != nil {
+			const errTitle = "Proxy stopped"
+			runtime.LogError(p.ctx, err.Error())
+			p.emitError(errTitle, err.Error())
+		}
+	}()
+	return nil
+}
+
+func (p *proxy) stop() {
+	p.gs.Stop()
+	p.client.close()
+}
+
+func (p *proxy) status() *proxyStatus {
+	return &proxyStatus{
+		Addr:        p.lis.Addr().String(),
+		WorkspaceID: p.workspaceID,
+		Target:      p.conn.opts.Addr,
+	}
+}
+
+// outgoingMetadata returns the incoming metadata that should be forwarded,
+// which excludes the headers set by the transport
+func outgoingMetadata(in metadata.MD) metadata.MD {
+	out := metadata.MD{}
+	for k, vs := range in {
+		if !reservedHeader(k) {
+			out[k] = vs
+		}
+	}
+	return out
+}
+
+func (p *proxy) handle(_ interface{}, ss grpc.ServerStream) error {
+	method, ok := grpc.MethodFromServerStream(ss)
+	if !ok {
+		return status.Error(codes.Internal, "proxy: no method in stream")
+	}
+	cc := p.client.grpcConn()
+	if cc == nil {
+		return status.Error(codes.Unavailable, "proxy: not connected to the target")
+	}
+
+	in, _ := metadata.FromIncomingContext(ss.Context())
+	header := outgoingMetadata(in)
+	ctx, cancel := context.WithCancel(metadata.NewOutgoingContext(ss.Context(), header))
+	defer cancel()
+
+	rec := &proxyCall{begin: time.Now()}
+	desc := &grpc.StreamDesc{ServerStreams: true, ClientStreams: true}
+	cs, err := cc.NewStream(ctx, desc, method, grpc.ForceCodec(rawCodec{}))
+	if err != nil {
+		p.record(method, header, nil, nil, rec, err)
+		return err
+	}
+
+	// Requests are forwarded until the client closes its side of the stream.
+	// A failure to forward one also ends the call, so it is reported by the
+	// target stream below.
+	go func() {
+		for {
+			f := &rawFrame{}
+			if err := ss.RecvMsg(f); err != nil {
+				if err == io.EOF {
+					cs.CloseSend()
+				}
+				return
+			}
+			rec.add(true, f)
+			if err := cs.SendMsg(f); err != nil {
+				return
+			}
+		}
+	}()
+
+	respHeader, err := cs.Header()
+	if err == nil {
+		err = ss.SendHeader(respHeader)
+	}
+	for err == nil {
+		f := &rawFrame{}
+		if err = cs.RecvMsg(f); err != nil {
+			break
+		}
+		rec.add(false, f)
+		err = ss.SendMsg(f)
+	}
+	if err == io.EOF {
+		err = nil
+	}
+	if err != nil {
+		// Stop forwarding requests, e.g. when the client went away
+		cancel()
+	}
+	trailer := cs.Trailer()
+	ss.SetTrailer(trailer)
+	p.record(method, header, respHeader, trailer, rec, err)
+	return err
+}
+
+// record saves the call in the history of the workspace, decoding its
+// messages with the workspace's proto files. Reflection calls made through
+// the proxy aren't recorded.
+func (p *proxy) record(method string, header, respHeader, trailer metadata.MD, rec *proxyCall, err error) {
+	if strings.HasPrefix(method, "/grpc.reflection.") {
+		return
+	}
+
+	st := status.Convert(err)
+	e := historyEntry{
+		ID:             fmt.Sprintf("%020d", rec.begin.UnixNano()),
+		Addr:           p.conn.opts.Addr,
+		Method:         method,
+		Started:        rec.begin,
+		Duration:       ms(time.Since(rec.begin)),
+		Header:         header,
+		ResponseHeader: respHeader,
+		Trailer:        trailer,
+		Status:         st.Code().String(),
+		StatusCode:     int32(st.Code()),
+		StatusMessage:  st.Message(),
+	}
+
+	md, derr := p.conn.methodDesc(method)
+	if derr == nil {
+		e.ClientStream = md.IsStreamingClient()
+		e.ServerStream = md.IsStreamingServer()
+	}
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	e.Phases = append(e.Phases, callPhase{Name: "begin"})
+	for _, f := range rec.frames {
+		var data string
+		err := derr
+		if err == nil {
+			data, err = decodeFrame(f, md)
+		}
+		if err != nil {
+			data = fmt.Sprintf("%q", err.Error())
+		}
+		e.Messages = append(e.Messages, recordedMessage{
+			Outbound:       f.outbound,
+			Offset:         ms(f.at.Sub(rec.begin)),
+			JSON:           data,
+			Size:           len(f.data),
+			CompressedSize: len(f.data),
+			WireSize:       len(f.data) + envelopeHeaderLen,
+		})
+		name := "in_payload"
+		if f.outbound {
+			name = "out_payload"
+		}
+		e.Phases = append(e.Phases, callPhase{Name: name, Offset: ms(f.at.Sub(rec.begin))})
+	}
+	e.Phases = append(e.Phases, callPhase{Name: "end", Offset: e.Duration})
+	go p.saveHistory(e)
+}
+
+func decodeFrame(f proxyFrame, md protoreflect.MethodDescriptor) (string, error) {
+	desc := md.Output()
+	if f.outbound {
+		desc = md.Input()
+	}
+	m := dynamicpb.NewMessage(desc)
+	if err := proto.Unmarshal(f.data, m); err != nil {
+		return "", err
+	}
+	return payloadJSON(m)
+}
+
+// StartProxy listens on the address in the options and forwards every call
+// to the target of the workspace in the options. Each forwarded call is
+// recorded in the history of that workspace. It replaces any proxy that is
+// already running.
+func (a *api) StartProxy(rawOpts interface{}) (st *proxyStatus, rerr error) {
+	defer func() {
+		if rerr != nil {
+			const errTitle = "Unable to start proxy"
+			runtime.LogError(a.ctx, rerr.Error())
+			a.emitError(errTitle, rerr.Error())
+		}
+	}()
+
+	var opts proxyOptions
+	if err := mapstructure.Decode(rawOpts, &opts); err != nil {
+		return nil, fmt.Errorf("failed to decode proxy options: %v", err)
+	}
+	if opts.Addr == "" {
+		opts.Addr = defaultProxyAddr
+	}
+	if opts.WorkspaceID == "" {
+		opts.WorkspaceID = a.currentID()
+	}
+
+	conn, err := a.openWorkspace(opts.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if isHTTPTransport(conn.opts.Transport) {
+		return nil, errors.New("the proxy only forwards to workspaces using the grpc transport")
+	}
+
+	a.StopProxy()
+
+	c := &client{}
+	if err := c.connect(conn.opts, nil); err != nil {
+		c.close()
+		return nil, fmt.Errorf("failed to connect to server: %v", err)
+	}
+	p := newProxy(a, opts.WorkspaceID, conn, c)
+	if err := p.serve(opts.Addr); err != nil {
+		c.close()
+		return nil, fmt.Errorf("failed to listen on %s: %v", opts.Addr, err)
+	}
+
+	a.mu.Lock()
+	a.proxy = p
+	a.mu.Unlock()
+	return p.status(), nil
+}
+
+// StopProxy stops the proxy if it is running
+func (a *api) StopProxy() {
+	a.mu.Lock()
+	p := a.proxy
+	a.proxy = nil
+	a.mu.Unlock()
+	if p != nil {
+		p.stop()
+	}
+}
+
+// GetProxy returns the address and target of the running proxy, or nil if it
+// isn't running
+func (a *api) GetProxy() *proxyStatus {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.proxy == nil {
+		return nil
+	}
+	return a.proxy.status()
+}
@@ -0,0 +1,362 @@
+package app
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// schemaFile documents a proto file of the workspace schema
+type schemaFile struct {
+	Name       string          `json:"name"`
+	Package    string          `json:"package"`
+	Syntax     string          `json:"syntax"`
+	Comments   comments        `json:"comments"`
+	Deprecated bool            `json:"deprecated"`
+	Options    string          `json:"options"`
+	Services   []schemaService `json:"services"`
+	Messages   []schemaMessage `json:"messages"`
+	Enums      []schemaEnum    `json:"enums"`
+}
+
+type schemaService struct {
+	Name       string         `json:"name"`
+	FullName   string         `json:"full_name"`
+	Comments   comments       `json:"comments"`
+	Deprecated bool           `json:"deprecated"`
+	Options    string         `json:"options"`
+	Methods    []schemaMethod `json:"methods"`
+}
+
+type schemaMethod struct {
+	Name         string   `json:"name"`
+	FullName     string   `json:"full_name"`
+	Input        string   `json:"input"`
+	Output       string   `json:"output"`
+	ClientStream bool     `json:"client_stream"`
+	ServerStream bool     `json:"server_stream"`
+	Comments     comments `json:"comments"`
+	Deprecated   bool     `json:"deprecated"`
+	Options      string   `json:"options"`
+}
+
+// schemaMessage documents a message; nested messages and enums are listed
+// with the top level ones of their file
+type schemaMessage struct {
+	Name       string        `json:"name"`
+	FullName   string        `json:"full_name"`
+	Comments   comments      `json:"comments"`
+	Deprecated bool          `json:"deprecated"`
+	Options    string        `json:"options"`
+	MapEntry   bool          `json:"map_entry"`
+	Fields     []schemaField `json:"fields"`
+	Oneofs     []schemaOneof `json:"oneofs"`
+}
+
+type schemaField struct {
+	Name        string `json:"name"`
+	FullName    string `json:"full_name"`
+	Number      int32  `json:"number"`
+	JSONName    string `json:"json_name"`
+	Kind        string `json:"kind"`
+	Cardinality string `json:"cardinality"`
+	// TypeName is the full name of the message or enum of the field
+	TypeName   string   `json:"type_name"`
+	Oneof      string   `json:"oneof"`
+	Optional   bool     `json:"optional"`
+	Default    string   `json:"default"`
+	Comments   comments `json:"comments"`
+	Deprecated bool     `json:"deprecated"`
+	Options    string   `json:"options"`
+}
+
+type schemaOneof struct {
+	Name     string   `json:"name"`
+	Comments comments `json:"comments"`
+	Fields   []string `json:"fields"`
+}
+
+type schemaEnum struct {
+	Name       string            `json:"name"`
+	FullName   string            `json:"full_name"`
+	Comments   comments          `json:"comments"`
+	Deprecated bool              `json:"deprecated"`
+	Options    string            `json:"options"`
+	Values     []schemaEnumValue `json:"values"`
+}
+
+type schemaEnumValue struct {
+	Name       string   `json:"name"`
+	Number     int32    `json:"number"`
+	Comments   comments `json:"comments"`
+	Deprecated bool     `json:"deprecated"`
+	Options    string   `json:"options"`
+}
+
+// descComments returns the comments of the descriptor from the source info
+// of its file, without the comment markers' leading space
+func descComments(d protoreflect.Descriptor) comments {
+	loc := d.ParentFile().SourceLocations().ByDescriptor(d)
+	return comments{
+		Leading:  trimComment(loc.LeadingComments),
+		Trailing: trimComment(loc.TrailingComments),
+	}
+}
+
+func trimComment(c string) string {
+	lines := strings.Split(strings.TrimRight(c, "\n"), "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimPrefix(strings.TrimRight(l, " \t"), " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// isDeprecated reports if the descriptor has the deprecated option set
+func isDeprecated(d protoreflect.Descriptor) bool {
+	type deprecatable interface {
+		GetDeprecated() bool
+	}
+	opts, ok := d.Options().(deprecatable)
+	return ok && opts.GetDeprecated()
+}
+
+// resolvedOptions returns the options of the descriptor with the custom
+// options parsed as extensions of the types, or nil if there are none.
+// Options of descriptors built at runtime keep custom options as unknown
+// fields.
+func resolvedOptions(d protoreflect.Descriptor, types *dynamicpb.Types) protoreflect.Message {
+	opts := d.Options()
+	if opts == nil {
+		return nil
+	}
+	b, err := proto.Marshal(opts)
+	if err != nil || len(b) == 0 {
+		return nil
+	}
+	m := opts.ProtoReflect().New()
+	if err := (proto.UnmarshalOptions{Resolver: types}).Unmarshal(b, m.Interface()); err != nil {
+		return nil
+	}
+	return m
+}
+
+// optionExtension returns the value of the custom option with the name, if
+// it is defined in the schema of the types and set on the descriptor
+func optionExtension(d protoreflect.Descriptor, name protoreflect.FullName, types *dynamicpb.Types) (protoreflect.Value, bool) {
+	xt, err := types.FindExtensionByName(name)
+	if err != nil {
+		return protoreflect.Value{}, false
+	}
+	opts := resolvedOptions(d, types)
+	if opts == nil || opts.Descriptor().FullName() != xt.TypeDescriptor().ContainingMessage().FullName() {
+		return protoreflect.Value{}, false
+	}
+	if !opts.Has(xt.TypeDescriptor()) {
+		return protoreflect.Value{}, false
+	}
+	return opts.Get(xt.TypeDescriptor()), true
+}
+
+// schemaDocs builds the documentation of the files, with custom options
+// resolved from the extensions defined in the files themselves
+type schemaDocs struct {
+	types *dynamicpb.Types
+}
+
+// options returns the options of the descriptor in the text format, one per
+// line, other than deprecated which is reported separately. Extensions that
+// aren't defined in the schema are shown by field number.
+func (s *schemaDocs) options(d protoreflect.Descriptor) string {
+	m := resolvedOptions(d, s.types)
+	if m == nil {
+		return ""
+	}
+	if fd := m.Descriptor().Fields().ByName("deprecated"); fd != nil {
+		m.Clear(fd)
+	}
+	return strings.TrimSpace(prototext.MarshalOptions{Multiline: true, Indent: "  ", EmitUnknown: true}.Format(m.Interface()))
+}
+
+func (s *schemaDocs) file(fd protoreflect.FileDescriptor) schemaFile {
+	f := schemaFile{
+		Name:       fd.Path(),
+		Package:    string(fd.Package()),
+		Syntax:     fd.Syntax().String(),
+		Deprecated: isDeprecated(fd),
+		Options:    s.options(fd),
+		Services:   []schemaService{},
+		Messages:   []schemaMessage{},
+		Enums:      []schemaEnum{},
+	}
+	// The comments of the file are those of its package or syntax statement
+	for _, path := range []protoreflect.SourcePath{{2}, {12}} {
+		loc := fd.SourceLocations().ByPath(path)
+		if loc.LeadingComments != "" || loc.TrailingComments != "" {
+			f.Comments = comments{Leading: trimComment(loc.LeadingComments), Trailing: trimComment(loc.TrailingComments)}
+			break
+		}
+	}
+
+	for i := 0; i < fd.Services().Len(); i++ {
+		f.Services = append(f.Services, s.service(fd.Services().Get(i)))
+	}
+	s.addMessages(&f, fd.Messages())
+	s.addEnums(&f, fd.Enums())
+	return f
+}
+
+func (s *schemaDocs) addMessages(f *schemaFile, mds protoreflect.MessageDescriptors) {
+	for i := 0; i < mds.Len(); i++ {
+		md := mds.Get(i)
+		f.Messages = append(f.Messages, s.message(md))
+		s.addMessages(f, md.Messages())
+		s.addEnums(f, md.Enums())
+	}
+}
+
+func (s *schemaDocs) addEnums(f *schemaFile, eds protoreflect.EnumDescriptors) {
+	for i := 0; i < eds.Len(); i++ {
+		f.Enums = append(f.Enums, s.enum(eds.Get(i)))
+	}
+}
+
+func (s *schemaDocs) service(sd protoreflect.ServiceDescriptor) schemaService {
+	svc := schemaService{
+		Name:       string(sd.Name()),
+		FullName:   string(sd.FullName()),
+		Comments:   descComments(sd),
+		Deprecated: isDeprecated(sd),
+		Options:    s.options(sd),
+		Methods:    []schemaMethod{},
+	}
+	for i := 0; i < sd.Methods().Len(); i++ {
+		md := sd.Methods().Get(i)
+		svc.Methods = append(svc.Methods, schemaMethod{
+			Name:         string(md.Name()),
+			FullName:     "/" + string(sd.FullName()) + "/" + string(md.Name()),
+			Input:        string(md.Input().FullName()),
+			Output:       string(md.Output().FullName()),
+			ClientStream: md.IsStreamingClient(),
+			ServerStream: md.IsStreamingServer(),
+			Comments:     descComments(md),
+			Deprecated:   isDeprecated(md),
+			Options:      s.options(md),
+		})
+	}
+	return svc
+}
+
+func (s *schemaDocs) message(md protoreflect.MessageDescriptor) schemaMessage {
+	msg := schemaMessage{
+		Name:       string(md.Name()),
+		FullName:   string(md.FullName()),
+		Comments:   descComments(md),
+		Deprecated: isDeprecated(md),
+		Options:    s.options(md),
+		MapEntry:   md.IsMapEntry(),
+		Fields:     []schemaField{},
+		Oneofs:     []schemaOneof{},
+	}
+	for i := 0; i < md.Fields().Len(); i++ {
+		msg.Fields = append(msg.Fields, s.field(md.Fields().Get(i)))
+	}
+	for i := 0; i < md.Oneofs().Len(); i++ {
+		od := md.Oneofs().Get(i)
+		if od.IsSynthetic() {
+			continue
+		}
+		o := schemaOneof{Name: string(od.Name()), Comments: descComments(od)}
+		for j := 0; j < od.Fields().Len(); j++ {
+			o.Fields = append(o.Fields, string(od.Fields().Get(j).Name()))
+		}
+		msg.Oneofs = append(msg.Oneofs, o)
+	}
+	return msg
+}
+
+func (s *schemaDocs) field(fd protoreflect.FieldDescriptor) schemaField {
+	f := schemaField{
+		Name:        string(fd.Name()),
+		FullName:    string(fd.FullName()),
+		Number:      int32(fd.Number()),
+		JSONName:    fd.JSONName(),
+		Kind:        fd.Kind().String(),
+		Cardinality: fd.Cardinality().String(),
+		Optional:    fd.HasPresence() && fd.ContainingOneof() != nil && fd.ContainingOneof().IsSynthetic(),
+		Comments:    descComments(fd),
+		Deprecated:  isDeprecated(fd),
+		Options:     s.options(fd),
+	}
+	if fd.IsMap() {
+		f.Kind = "map"
+	}
+	if m := fd.Message(); m != nil {
+		f.TypeName = string(m.FullName())
+	} else if e := fd.Enum(); e != nil {
+		f.TypeName = string(e.FullName())
+	}
+	if od := fd.ContainingOneof(); od != nil && !od.IsSynthetic() {
+		f.Oneof = string(od.Name())
+	}
+	if fd.HasDefault() {
+		if e := fd.DefaultEnumValue(); e != nil {
+			f.Default = string(e.Name())
+		} else {
+			f.Default = fd.Default().String()
+		}
+	}
+	return f
+}
+
+func (s *schemaDocs) enum(ed protoreflect.EnumDescriptor) schemaEnum {
+	e := schemaEnum{
+		Name:       string(ed.Name()),
+		FullName:   string(ed.FullName()),
+		Comments:   descComments(ed),
+		Deprecated: isDeprecated(ed),
+		Options:    s.options(ed),
+		Values:     []schemaEnumValue{},
+	}
+	for i := 0; i < ed.Values().Len(); i++ {
+		vd := ed.Values().Get(i)
+		e.Values = append(e.Values, schemaEnumValue{
+			Name:       string(vd.Name()),
+			Number:     int32(vd.Number()),
+			Comments:   descComments(vd),
+			Deprecated: isDeprecated(vd),
+			Options:    s.options(vd),
+		})
+	}
+	return e
+}
+
+// schemaFiles documents every file of the schema, sorted by name
+func schemaFiles(files *protoregistry.Files) []schemaFile {
+	s := &schemaDocs{types: dynamicpb.NewTypes(files)}
+	docs := []schemaFile{}
+	files.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		docs = append(docs, s.file(fd))
+		return true
+	})
+	sort.Slice(docs, func(i, j int) bool {
+		return docs[i].Name < docs[j].Name
+	})
+	return docs
+}
+
+// GetSchema returns the documentation of the proto files of the current
+// workspace: the comments, deprecation and options of their services,
+// methods, messages, fields and enums
+func (a *api) GetSchema() ([]schemaFile, error) {
+	conn := a.current()
+	if conn == nil || conn.files() == nil {
+		return nil, errors.New("no proto files loaded")
+	}
+	return schemaFiles(conn.files()), nil
+}
@@ -0,0 +1,396 @@
+package app
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	stepSend      = "send"
+	stepDelay     = "delay"
+	stepWaitCount = "wait_count"
+	stepWaitMatch = "wait_match"
+	stepCloseSend = "close_send"
+)
+
+// streamStep is a single step of a stream script. Which fields are used
+// depends on the kind of step:
+//
+//	send:       Message is the request to send, in the format of the workspace
+//	delay:      Delay is the number of seconds to pause for
+//	wait_count: Count is the number of further responses to wait for
+//	wait_match: Match is the JSON a further response must contain
+//	close_send: no fields
+//
+// Timeout is the number of seconds a wait step fails after, or 0 to wait
+// until the call ends.
+type streamStep struct {
+	Kind    string  `json:"kind"`
+	Message string  `json:"message"`
+	Delay   float64 `json:"delay"`
+	Count   int     `json:"count"`
+	Match   string  `json:"match"`
+	Timeout float64 `json:"timeout"`
+}
+
+// streamScript is a repeatable sequence of steps for a client or
+// bidirectional stream
+type streamScript struct {
+	Name  string       `json:"name"`
+	Steps []streamStep `json:"steps"`
+}
+
+type scriptStep struct {
+	CallID string `json:"call_id"`
+	Step   int    `json:"step"`
+	Kind   string `json:"kind"`
+	Done   bool   `json:"done"`
+	Error  string `json:"error"`
+}
+
+type scriptEnd struct {
+	CallID string `json:"call_id"`
+	Error  string `json:"error"`
+}
+
+// scriptRun tracks the responses of a call while its script is run
+type scriptRun struct {
+	c      *call
+	steps  []streamStep
+	reqs   []proto.Message // per step, for send steps
+	match  []interface{}   // per step, for wait_match steps
+	notify chan struct{}
+
+	mu    sync.Mutex
+	resps []proto.Message
+	next  int // index of the first response not yet waited for
+}
+
+func scriptKey(addr, method, name string) []byte {
+	return []byte(scriptKeyPrefix + hash(addr, method) + "_" + hash(name))
+}
+
+// newScriptRun checks every step of the script up front, so a mistake in a
+// later step doesn't leave a call half finished
+func newScriptRun(c *call, steps []streamStep) (*scriptRun, error) {
+	r := &scriptRun{
+		c:      c,
+		steps:  steps,
+		reqs:   make([]proto.Message, len(steps)),
+		match:  make([]interface{}, len(steps)),
+		notify: make(chan struct{}, 1),
+	}
+	for i, s := range steps {
+		switch s.Kind {
+		case stepSend:
+			req, err := c.conn.opts.requestFormat().decode(c.desc.Input(), []byte(s.Message))
+			if err != nil {
+				return nil, fmt.Errorf("step %d: failed to unmarshal request: %v", i+1, err)
+			}
+			r.reqs[i] = req
+		case stepDelay:
+			if s.Delay < 0 {
+				return nil, fmt.Errorf("step %d: delay must not be negative", i+1)
+			}
+		case stepWaitCount:
+			if s.Count <= 0 {
+				return nil, fmt.Errorf("step %d: count must be at least 1", i+1)
+			}
+		case stepWaitMatch:
+			if err := json.Unmarshal([]byte(s.Match), &r.match[i]); err != nil {
+				return nil, fmt.Errorf("step %d: invalid match JSON: %v", i+1, err)
+			}
+		case stepCloseSend:
+		default:
+			return nil, fmt.Errorf("step %d: unknown step kind %q", i+1, s.Kind)
+		}
+	}
+	c.onRecv = r.received
+	return r, nil
+}
+
+func (r *scriptRun) received(m proto.Message) {
+	r.mu.Lock()
+	r.resps = append(r.resps, m)
+	r.mu.Unlock()
+	select {
+	case r.notify <- struct{}{}:
+	default:
+	}
+}
+
+// wait blocks until ok reports true for the responses not yet waited for.
+// ok returns how many of those responses it used.
+func (r *scriptRun) wait(timeout float64, ok func([]proto.Message) (int, bool)) error {
+	var expired <-chan time.Time
+	if timeout > 0 {
+		t := time.NewTimer(seconds(timeout))
+		defer t.Stop()
+		expired = t.C
+	}
+	for {
+		r.mu.Lock()
+		n, found := ok(r.resps[r.next:])
+		if found {
+			r.next += n
+		}
+		r.mu.Unlock()
+		if found {
+			return nil
+		}
+
+		select {
+		case <-r.notify:
+		case <-r.c.done:
+			// The last responses may have arrived with the end of the call
+			r.mu.Lock()
+			_, found := ok(r.resps[r.next:])
+			r.mu.Unlock()
+			if found {
+				return nil
+			}
+			return errCallEnded
+		case <-expired:
+			return errors.New("timed out waiting for responses")
+		}
+	}
+}
+
+func (r *scriptRun) step(i int) error {
+	s := r.steps[i]
+	switch s.Kind {
+	case stepSend:
+		return r.c.send(r.reqs[i])
+	case stepDelay:
+		select {
+		case <-time.After(seconds(s.Delay)):
+			return nil
+		case <-r.c.done:
+			return errCallEnded
+		}
+	case stepWaitCount:
+		return r.wait(s.Timeout, func(resps []proto.Message) (int, bool) {
+			return s.Count, len(resps) >= s.Count
+		})
+	case stepWaitMatch:
+		return r.wait(s.Timeout, func(resps []proto.Message) (int, bool) {
+			for n, m := range resps {
+				if responseMatches(m, r.match[i]) {
+					return n + 1, true
+				}
+			}
+			return 0, false
+		})
+	case stepCloseSend:
+		r.c.closeSend()
+	}
+	return nil
+}
+
+// responseMatches reports if the response contains all the fields of want,
+// which is decoded JSON. Field names may be in either their JSON or proto
+// form.
+func responseMatches(m proto.Message, want interface{}) bool {
+	b, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(m)
+	if err != nil {
+		return false
+	}
+	var got interface{}
+	if err := json.Unmarshal(b, &got); err != nil {
+		return false
+	}
+	return jsonContains(got, want)
+}
+
+func jsonContains(got, want interface{}) bool {
+	switch w := want.(type) {
+	case map[string]interface{}:
+		g, ok := got.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for k, wv := range w {
+			gv, ok := g[k]
+			if !ok {
+				gv, ok = g[jsonCamelCase(k)]
+			}
+			if !ok || !jsonContains(gv, wv) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		g, ok := got.([]interface{})
+		if !ok || len(g) != len(w) {
+			return false
+		}
+		for i := range w {
+			if !jsonContains(g[i], w[i]) {
+				return false
+			}
+		}
+		return true
+	case float64:
+		// protojson encodes 64 bit integers as strings
+		if s, ok := got.(string); ok {
+			return s == fmt.Sprint(int64(w)) && float64(int64(w)) == w
+		}
+	}
+	return reflect.DeepEqual(got, want)
+}
+
+// jsonCamelCase converts a proto field name to its default JSON name
+func jsonCamelCase(s string) string {
+	var b strings.Builder
+	upper := false
+	for _, c := range s {
+		if c == '_' {
+			upper = true
+			continue
+		}
+		if upper && 'a' <= c && c <= 'z' {
+			c -= 'a' - 'A'
+		}
+		upper = false
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+// RunStreamScript starts the client or bidirectional stream method on the
+// current workspace and runs the script steps against it in order. The
+// stream is closed for sending after the last step. It returns the call ID,
+// which can be used to cancel the call and so the script.
+func (a *api) RunStreamScript(method string, rawScript interface{}, rawHeaders interface{}) (id string, rerr error) {
+	defer func() {
+		if rerr != nil {
+			const errTitle = "Unable to run stream script"
+			runtime.LogError(a.ctx, rerr.Error())
+			a.emitError(errTitle, rerr.Error())
+		}
+	}()
+
+	var script streamScript
+	if err := mapstructure.Decode(rawScript, &script); err != nil {
+		return "", fmt.Errorf("failed to decode stream script: %v", err)
+	}
+
+	c, err := a.openCall(a.currentID(), method, rawHeaders)
+	if err != nil {
+		return "", err
+	}
+	if !c.desc.IsStreamingClient() {
+		c.cancel()
+		return "", fmt.Errorf("method %s is not a client or bidirectional stream", method)
+	}
+	run, err := newScriptRun(c, script.Steps)
+	if err != nil {
+		c.cancel()
+		return "", err
+	}
+	for i, req := range run.reqs {
+		if req == nil {
+			continue
+		}
+		if err := a.validateRequest(c.conn, method, req); err != nil {
+			c.cancel()
+			return "", fmt.Errorf("step %d: %v", i+1, err)
+		}
+	}
+	a.trackCall(c, nil)
+
+	go func() {
+		if err := a.runCall(c, nil); err != nil {
+			const errTitle = "Unable to send request"
+			runtime.LogError(a.ctx, err.Error())
+			runtime.EventsEmit(a.ctx, eventError, errorMsg{errTitle, err.Error()}, c.id)
+		}
+	}()
+
+	go func() {
+		var end scriptEnd
+		end.CallID = c.id
+		for i, s := range script.Steps {
+			runtime.EventsEmit(a.ctx, eventScriptStep, scriptStep{CallID: c.id, Step: i, Kind: s.Kind})
+			err := run.step(i)
+			ev := scriptStep{CallID: c.id, Step: i, Kind: s.Kind, Done: true}
+			if err != nil {
+				ev.Error = err.Error()
+				end.Error = fmt.Sprintf("step %d (%s): %v", i+1, s.Kind, err)
+			}
+			runtime.EventsEmit(a.ctx, eventScriptStep, ev)
+			if err != nil {
+				break
+			}
+		}
+		c.closeSend()
+		runtime.EventsEmit(a.ctx, eventScriptEnded, end)
+	}()
+
+	return c.id, nil
+}
+
+// SaveStreamScript stores the script for the method on the current
+// workspace, replacing any script with the same name
+func (a *api) SaveStreamScript(method string, rawScript interface{}) error {
+	var script streamScript
+	if err := mapstructure.Decode(rawScript, &script); err != nil {
+		return fmt.Errorf("failed to decode stream script: %v", err)
+	}
+	if script.Name == "" {
+		return errors.New("stream script has no name")
+	}
+	opts, err := a.GetWorkspaceOptions()
+	if err != nil {
+		return err
+	}
+	var val bytes.Buffer
+	if err := gob.NewEncoder(&val).Encode(script); err != nil {
+		return err
+	}
+	return a.store.set(scriptKey(opts.Addr, method, script.Name), val.Bytes())
+}
+
+// ListStreamScripts returns the saved scripts for the method on the current
+// workspace
+func (a *api) ListStreamScripts(method string) ([]streamScript, error) {
+	opts, err := a.GetWorkspaceOptions()
+	if err != nil {
+		return nil, err
+	}
+	items, err := a.store.list([]byte(scriptKeyPrefix + hash(opts.Addr, method) + "_"))
+	if err != nil {
+		return nil, err
+	}
+	var scripts []streamScript
+	for _, val := range items {
+		var script streamScript
+		if err := gob.NewDecoder(bytes.NewBuffer(val)).Decode(&script); err != nil {
+			return scripts, err
+		}
+		scripts = append(scripts, script)
+	}
+	return scripts, nil
+}
+
+// DeleteStreamScript removes a saved script for the method on the current
+// workspace
+func (a *api) DeleteStreamScript(method, name string) error {
+	opts, err := a.GetWorkspaceOptions()
+	if err != nil {
+		return err
+	}
+	return a.store.del(scriptKey(opts.Addr, method, name))
+}
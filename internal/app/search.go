@@ -0,0 +1,297 @@
+package app
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"unicode"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+const defaultSearchLimit = 50
+
+const (
+	symbolService   = "service"
+	symbolMethod    = "method"
+	symbolMessage   = "message"
+	symbolEnum      = "enum"
+	symbolField     = "field"
+	symbolEnumValue = "enum_value"
+)
+
+// symbolKindRank orders matches of equal score
+var symbolKindRank = map[string]int{
+	symbolService:   0,
+	symbolMethod:    1,
+	symbolMessage:   2,
+	symbolEnum:      3,
+	symbolField:     4,
+	symbolEnumValue: 5,
+}
+
+// symbolMatch is a search result. Methods are the methods that use the
+// symbol: those of a service, the method itself, or those whose request or
+// response contains the message or enum of the symbol at any depth.
+type symbolMatch struct {
+	Kind       string   `json:"kind"`
+	Name       string   `json:"name"`
+	FullName   string   `json:"full_name"`
+	File       string   `json:"file"`
+	Deprecated bool     `json:"deprecated"`
+	Score      int      `json:"score"`
+	Methods    []string `json:"methods"`
+}
+
+type symbol struct {
+	kind string
+	desc protoreflect.Descriptor
+	// owner is the type whose users are the users of the symbol
+	owner protoreflect.FullName
+}
+
+// symbolIndex holds the symbols of a schema along with the methods that
+// use each message and enum
+type symbolIndex struct {
+	symbols []symbol
+	usedBy  map[protoreflect.FullName][]string
+}
+
+func newSymbolIndex(files *protoregistry.Files) *symbolIndex {
+	idx := &symbolIndex{usedBy: make(map[protoreflect.FullName][]string)}
+	files.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		for i := 0; i < fd.Services().Len(); i++ {
+			sd := fd.Services().Get(i)
+			idx.add(symbolService, sd, sd.FullName())
+			for j := 0; j < sd.Methods().Len(); j++ {
+				md := sd.Methods().Get(j)
+				path := methodPath(md)
+				idx.add(symbolMethod, md, md.FullName())
+				idx.usedBy[md.FullName()] = []string{path}
+				idx.usedBy[sd.FullName()] = append(idx.usedBy[sd.FullName()], path)
+
+				seen := make(map[protoreflect.FullName]bool)
+				idx.use(md.Input(), path, seen)
+				idx.use(md.Output(), path, seen)
+			}
+		}
+		idx.addMessages(fd.Messages())
+		idx.addEnums(fd.Enums())
+		return true
+	})
+	for _, methods := range idx.usedBy {
+		sort.Strings(methods)
+	}
+	return idx
+}
+
+func methodPath(md protoreflect.MethodDescriptor) string {
+	return "/" + string(md.Parent().FullName()) + "/" + string(md.Name())
+}
+
+func (idx *symbolIndex) add(kind string, d protoreflect.Descriptor, owner protoreflect.FullName) {
+	idx.symbols = append(idx.symbols, symbol{kind: kind, desc: d, owner: owner})
+}
+
+func (idx *symbolIndex) addMessages(mds protoreflect.MessageDescriptors) {
+	for i := 0; i < mds.Len(); i++ {
+		md := mds.Get(i)
+		if md.IsMapEntry() {
+			continue
+		}
+		idx.add(symbolMessage, md, md.FullName())
+		for j := 0; j < md.Fields().Len(); j++ {
+			idx.add(symbolField, md.Fields().Get(j), md.FullName())
+		}
+		idx.addMessages(md.Messages())
+		idx.addEnums(md.Enums())
+	}
+}
+
+func (idx *symbolIndex) addEnums(eds protoreflect.EnumDescriptors) {
+	for i := 0; i < eds.Len(); i++ {
+		ed := eds.Get(i)
+		idx.add(symbolEnum, ed, ed.FullName())
+		for j := 0; j < ed.Values().Len(); j++ {
+			idx.add(symbolEnumValue, ed.Values().Get(j), ed.FullName())
+		}
+	}
+}
+
+// use records the method as a user of the message and of every message and
+// enum it contains
+func (idx *symbolIndex) use(md protoreflect.MessageDescriptor, method string, seen map[protoreflect.FullName]bool) {
+	if seen[md.FullName()] {
+		return
+	}
+	seen[md.FullName()] = true
+	idx.usedBy[md.FullName()] = append(idx.usedBy[md.FullName()], method)
+	for i := 0; i < md.Fields().Len(); i++ {
+		fd := md.Fields().Get(i)
+		if fd.IsMap() {
+			fd = fd.MapValue()
+		}
+		if m := fd.Message(); m != nil {
+			idx.use(m, method, seen)
+		} else if e := fd.Enum(); e != nil && !seen[e.FullName()] {
+			seen[e.FullName()] = true
+			idx.usedBy[e.FullName()] = append(idx.usedBy[e.FullName()], method)
+		}
+	}
+}
+
+// search returns the symbols matching the query, best first. Queries with
+// a dot or slash are matched against the full name, others the name.
+func (idx *symbolIndex) search(query string, limit int) []symbolMatch {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return []symbolMatch{}
+	}
+	full := strings.ContainsAny(query, "./")
+	query = strings.ReplaceAll(query, "/", ".")
+
+	matches := []symbolMatch{}
+	for _, s := range idx.symbols {
+		target := string(s.desc.Name())
+		if full {
+			target = string(s.desc.FullName())
+		}
+		score := fuzzyScore(query, target)
+		if score < 0 {
+			continue
+		}
+		methods := idx.usedBy[s.owner]
+		if methods == nil {
+			methods = []string{}
+		}
+		matches = append(matches, symbolMatch{
+			Kind:       s.kind,
+			Name:       string(s.desc.Name()),
+			FullName:   string(s.desc.FullName()),
+			File:       s.desc.ParentFile().Path(),
+			Deprecated: isDeprecated(s.desc),
+			Score:      score,
+			Methods:    methods,
+		})
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if symbolKindRank[a.Kind] != symbolKindRank[b.Kind] {
+			return symbolKindRank[a.Kind] < symbolKindRank[b.Kind]
+		}
+		return a.FullName < b.FullName
+	})
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+// normalizeSymbol lowercases the name and drops the separators that differ
+// between naming conventions, so OrderId, orderId and order_id are equal
+func normalizeSymbol(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || r == '-' || unicode.IsSpace(r) {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, s)
+}
+
+// fuzzyScore returns how well the query matches the target as a
+// subsequence, ignoring case and separators, or -1 if it doesn't. Exact and
+// prefix matches rank first, then matches on word boundaries and runs of
+// consecutive characters; gaps and longer targets score lower.
+func fuzzyScore(query, target string) int {
+	q := []rune(normalizeSymbol(query))
+	if len(q) == 0 {
+		return -1
+	}
+	norm := normalizeSymbol(target)
+	switch {
+	case norm == string(q):
+		return 10000
+	case strings.HasPrefix(norm, string(q)):
+		return 5000 - (len(norm) - len(q))
+	}
+
+	t := []rune(target)
+	// boundary reports if the target rune starts a word
+	boundary := func(i int) bool {
+		if i == 0 {
+			return true
+		}
+		prev, cur := t[i-1], t[i]
+		return prev == '_' || prev == '.' || prev == '-' ||
+			(unicode.IsLower(prev) && unicode.IsUpper(cur)) ||
+			(unicode.IsLetter(prev) && unicode.IsDigit(cur))
+	}
+
+	best := -1
+	// Each occurrence of the first rune is tried as the start of the match
+	for start := range t {
+		if unicode.ToLower(t[start]) != q[0] {
+			continue
+		}
+		score, qi, last := 0, 0, -1
+		for i := start; i < len(t) && qi < len(q); i++ {
+			c := unicode.ToLower(t[i])
+			if c == '_' || c == '-' {
+				continue
+			}
+			if c != q[qi] {
+				continue
+			}
+			score += 10
+			if boundary(i) {
+				score += 20
+			}
+			if last >= 0 {
+				if gap := i - last - 1; gap == 0 || (gap == 1 && (t[i-1] == '_' || t[i-1] == '-')) {
+					score += 15
+				} else {
+					score -= gap
+				}
+			}
+			last = i
+			qi++
+		}
+		if qi < len(q) {
+			break
+		}
+		if start == 0 {
+			score += 25
+		}
+		score -= len(t) - len(q)
+		if score < 0 {
+			score = 0
+		}
+		if score > best {
+			best = score
+		}
+	}
+	return best
+}
+
+// SearchSymbols searches the schema of the current workspace for services,
+// methods, messages, fields, enums and enum values matching the query, up
+// to the limit or 50 results if zero
+func (a *api) SearchSymbols(query string, limit int) ([]symbolMatch, error) {
+	conn := a.current()
+	if conn == nil {
+		return nil, errors.New("no proto files loaded")
+	}
+	idx := conn.symbolIndex()
+	if idx == nil {
+		return nil, errors.New("no proto files loaded")
+	}
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	return idx.search(query, limit), nil
+}
@@ -0,0 +1,124 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/stats"
+)
+
+type ctxAttemptsKey struct{}
+
+// callAttempts counts the attempts grpc makes for a single call, including
+// retries and hedged requests driven by the service config. grpc reports the
+// stats of each attempt, so the call ends once it has returned and none of
+// its attempts is in flight.
+type callAttempts struct {
+	mu          sync.Mutex
+	total       int
+	transparent int
+	active      int
+	returned    bool
+	ended       bool
+	// first is the start of the first attempt, and last the end of the last
+	// attempt to end
+	first time.Time
+	last  *stats.End
+}
+
+func (c *callAttempts) begin(s *stats.Begin) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.total == 0 {
+		c.first = s.BeginTime
+	}
+	c.total++
+	c.active++
+	if s.IsTransparentRetryAttempt {
+		c.transparent++
+	}
+	return c.total
+}
+
+func (c *callAttempts) count() (total, transparent int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.total, c.transparent
+}
+
+// end records the end of an attempt, returning true if it ends the call
+func (c *callAttempts) end(s *stats.End) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.active--
+	c.last = s
+	return c.endedLocked()
+}
+
+// finish records that the call has returned with err, returning true if it
+// ends the call. The attempts of a cancelled call may end after it has
+// returned. A call that fails before grpc starts its first attempt has no
+// attempt to end it, so it ends with err instead.
+func (c *callAttempts) finish(err error) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.returned = true
+	if c.total == 0 && c.last == nil {
+		now := time.Now()
+		c.first = now
+		c.last = &stats.End{Client: true, BeginTime: now, EndTime: now, Error: err}
+	}
+	return c.endedLocked()
+}
+
+// endedLocked must be called with c.mu held
+func (c *callAttempts) endedLocked() bool {
+	if c.ended || !c.returned || c.active > 0 || c.last == nil {
+		return false
+	}
+	c.ended = true
+	return true
+}
+
+// result returns the end of the last attempt to end and the duration of the
+// call from the start of its first attempt
+func (c *callAttempts) result() (*stats.End, time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.last, c.last.EndTime.Sub(c.first)
+}
+
+func attemptsFromContext(ctx context.Context) *callAttempts {
+	ca, _ := ctx.Value(ctxAttemptsKey{}).(*callAttempts)
+	return ca
+}
+
+// validateServiceConfig checks that raw is a service config that grpc will
+// accept. An empty config is valid and means the grpc defaults are used.
+func validateServiceConfig(raw string) error {
+	if strings.TrimSpace(raw) == "" {
+		return nil
+	}
+
+	var js map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &js); err != nil {
+		return fmt.Errorf("service config is not a valid JSON object: %v", err)
+	}
+
+	// grpc parses the default service config when the client is created, so
+	// use a client that never connects to get the exact same validation.
+	conn, err := grpc.NewClient("passthrough:///service-config-validation",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultServiceConfig(raw),
+	)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
@@ -0,0 +1,858 @@
+package app
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// codeSnippet is client code for a call in a language
+type codeSnippet struct {
+	Language string `json:"language"`
+	Name     string `json:"name"`
+	Code     string `json:"code"`
+}
+
+type snippetHeader struct {
+	Key string
+	Val string
+	// Binary headers have their value base64 encoded in Val
+	Binary bool
+}
+
+// snippetData is the call and the names of its types in each language
+type snippetData struct {
+	Addr       string
+	Plaintext  bool
+	Insecure   bool
+	RootCA     bool
+	ClientCert bool
+	ServerName string
+	Authority  string
+	// The call timeout in both units, zero if there is none
+	TimeoutMs      int64
+	TimeoutSeconds float64
+
+	Method       string
+	ProtoFile    string
+	Service      string
+	ServiceName  string
+	MethodName   string
+	ClientStream bool
+	ServerStream bool
+	Body         string
+	Metadata     []snippetHeader
+	// BinaryMetadata is set if any of the headers is binary
+	BinaryMetadata bool
+
+	// Dynamic is set for the Go snippet that uses dynamicpb, rather than
+	// generated code
+	Dynamic   bool
+	GoImport  string
+	GoPackage string
+	GoService string
+	GoMethod  string
+	GoInput   string
+	// The package of the request type, if it isn't that of the method
+	GoInputImport  string
+	GoInputPackage string
+
+	PyModule string
+	PyInput  string
+
+	JavaPackage string
+	JavaService string
+	JavaMethod  string
+	JavaInput   string
+	JavaOutput  string
+}
+
+func isASCIILower(c byte) bool { return 'a' <= c && c <= 'z' }
+func isASCIIUpper(c byte) bool { return 'A' <= c && c <= 'Z' }
+func isASCIIDigit(c byte) bool { return '0' <= c && c <= '9' }
+
+// goCamelCase converts a proto name to the Go identifier protoc-gen-go
+// generates for it
+func goCamelCase(s string) string {
+	var b []byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '.' && i+1 < len(s) && isASCIILower(s[i+1]):
+			// Skipped, as the next letter is capitalized
+		case c == '.':
+			b = append(b, '_')
+		case c == '_' && (i == 0 || s[i-1] == '.'):
+			b = append(b, 'X')
+		case c == '_' && i+1 < len(s) && isASCIILower(s[i+1]):
+			// Skipped, as the next letter is capitalized
+		case isASCIIDigit(c):
+			b = append(b, c)
+		default:
+			if isASCIILower(c) {
+				c -= 'a' - 'A'
+			}
+			b = append(b, c)
+			for ; i+1 < len(s) && isASCIILower(s[i+1]); i++ {
+				b = append(b, s[i+1])
+			}
+		}
+	}
+	return string(b)
+}
+
+// relativeName is the name of the message within its package, with the
+// names of the messages it is nested in
+func relativeName(md protoreflect.MessageDescriptor) string {
+	pkg := md.ParentFile().Package()
+	name := string(md.FullName())
+	if pkg != "" {
+		name = strings.TrimPrefix(name, string(pkg)+".")
+	}
+	return name
+}
+
+// goImport returns the import path and package name of the Go code
+// generated for the file
+func goImport(fd protoreflect.FileDescriptor) (string, string) {
+	opts, _ := fd.Options().(*descriptorpb.FileOptions)
+	goPkg := opts.GetGoPackage()
+	if goPkg == "" {
+		// Nowhere to tell, so a placeholder
+		dir := path.Dir(fd.Path())
+		if dir == "." {
+			dir = strings.ReplaceAll(string(fd.Package()), ".", "/")
+		}
+		goPkg = "example.com/gen/" + dir
+	}
+	importPath, name, ok := strings.Cut(goPkg, ";")
+	if !ok {
+		name = path.Base(importPath)
+	}
+	return importPath, goIdent(name)
+}
+
+// goIdent replaces the characters of a path element that can't be in a Go
+// identifier
+func goIdent(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == '.' {
+			return '_'
+		}
+		return r
+	}, s)
+}
+
+// pyModule is the module generated by grpcio-tools for the file, without
+// its _pb2 suffix
+func pyModule(fd protoreflect.FileDescriptor) string {
+	p := strings.TrimSuffix(fd.Path(), ".proto")
+	p = strings.ReplaceAll(p, "-", "_")
+	return strings.ReplaceAll(p, "/", ".")
+}
+
+// javaCamelCase converts a file name to the outer class name protoc
+// generates for it
+func javaCamelCase(s string, upperFirst bool) string {
+	var b []byte
+	upper := upperFirst
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case isASCIILower(c):
+			if upper {
+				c -= 'a' - 'A'
+			}
+			b = append(b, c)
+			upper = false
+		case isASCIIUpper(c):
+			if i == 0 && !upperFirst {
+				c += 'a' - 'A'
+			}
+			b = append(b, c)
+			upper = false
+		case isASCIIDigit(c):
+			b = append(b, c)
+			upper = true
+		default:
+			upper = true
+		}
+	}
+	return string(b)
+}
+
+// javaOuterClass is the class holding the messages of the file, unless
+// they are generated in files of their own
+func javaOuterClass(fd protoreflect.FileDescriptor) string {
+	opts, _ := fd.Options().(*descriptorpb.FileOptions)
+	if name := opts.GetJavaOuterClassname(); name != "" {
+		return name
+	}
+	name := javaCamelCase(strings.TrimSuffix(path.Base(fd.Path()), ".proto"), true)
+	// protoc adds a suffix if the name is taken by a type of the file
+	conflict := false
+	for i := 0; i < fd.Messages().Len(); i++ {
+		conflict = conflict || string(fd.Messages().Get(i).Name()) == name
+	}
+	for i := 0; i < fd.Enums().Len(); i++ {
+		conflict = conflict || string(fd.Enums().Get(i).Name()) == name
+	}
+	for i := 0; i < fd.Services().Len(); i++ {
+		conflict = conflict || string(fd.Services().Get(i).Name()) == name
+	}
+	if conflict {
+		name += "OuterClass"
+	}
+	return name
+}
+
+func javaPackage(fd protoreflect.FileDescriptor) string {
+	opts, _ := fd.Options().(*descriptorpb.FileOptions)
+	if pkg := opts.GetJavaPackage(); pkg != "" {
+		return pkg
+	}
+	return string(fd.Package())
+}
+
+// javaClass is the class of the message, relative to the Java package of
+// the file of the method
+func javaClass(md protoreflect.MessageDescriptor, from protoreflect.FileDescriptor) string {
+	fd := md.ParentFile()
+	name := relativeName(md)
+	opts, _ := fd.Options().(*descriptorpb.FileOptions)
+	if !opts.GetJavaMultipleFiles() {
+		name = javaOuterClass(fd) + "." + name
+	}
+	if javaPackage(fd) != javaPackage(from) {
+		name = javaPackage(fd) + "." + name
+	}
+	return name
+}
+
+// pyClass is the class of the message in its _pb2 module, qualified with
+// the module if it's from a different file to the method
+func pyClass(md protoreflect.MessageDescriptor, from protoreflect.FileDescriptor) string {
+	mod := path.Base(strings.ReplaceAll(pyModule(md.ParentFile()), ".", "/")) + "_pb2"
+	if md.ParentFile().Path() != from.Path() {
+		mod = pyModule(md.ParentFile()) + "_pb2"
+	}
+	return mod + "." + relativeName(md)
+}
+
+func newSnippetData(opts options, md protoreflect.MethodDescriptor, rawJSON []byte, hs headers) (*snippetData, error) {
+	req, err := opts.requestFormat().decode(md.Input(), rawJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal request: %v", err)
+	}
+	// Proto names are understood by the JSON parsers of every language,
+	// and are what proto-loader expects in Node
+	body, err := protojson.MarshalOptions{UseProtoNames: true, Multiline: true, Indent: "  "}.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	sd := md.Parent().(protoreflect.ServiceDescriptor)
+	fd := md.ParentFile()
+	d := &snippetData{
+		Addr:           opts.Addr,
+		Plaintext:      opts.Plaintext,
+		Insecure:       opts.Insecure,
+		RootCA:         opts.Rootca != "",
+		ClientCert:     opts.Clientcert != "",
+		ServerName:     opts.ServerName,
+		Authority:      opts.Authority,
+		TimeoutMs:      seconds(opts.Timeout).Milliseconds(),
+		TimeoutSeconds: opts.Timeout,
+
+		Method:       fmt.Sprintf("/%s/%s", sd.FullName(), md.Name()),
+		ProtoFile:    fd.Path(),
+		Service:      string(sd.FullName()),
+		ServiceName:  string(sd.Name()),
+		MethodName:   string(md.Name()),
+		ClientStream: md.IsStreamingClient(),
+		ServerStream: md.IsStreamingServer(),
+		Body:         string(body),
+
+		GoService: goCamelCase(string(sd.Name())),
+		GoMethod:  goCamelCase(string(md.Name())),
+
+		PyModule: pyModule(fd),
+		PyInput:  pyClass(md.Input(), fd),
+
+		JavaPackage: javaPackage(fd),
+		JavaService: string(sd.Name()) + "Grpc",
+		JavaMethod:  javaCamelCase(string(md.Name()), false),
+		JavaInput:   javaClass(md.Input(), fd),
+		JavaOutput:  javaClass(md.Output(), fd),
+	}
+	d.GoImport, d.GoPackage = goImport(fd)
+	d.GoInput = d.GoPackage + "." + goCamelCase(relativeName(md.Input()))
+	if imp, pkg := goImport(md.Input().ParentFile()); imp != d.GoImport {
+		if pkg == d.GoPackage {
+			// Named after its parent directory as well, such as typesv1
+			pkg = goIdent(path.Base(path.Dir(imp))) + pkg
+		}
+		d.GoInputImport, d.GoInputPackage = imp, pkg
+		d.GoInput = pkg + "." + goCamelCase(relativeName(md.Input()))
+	}
+
+	for _, h := range hs {
+		if h.Key == "" {
+			continue
+		}
+		sh := snippetHeader{Key: strings.ToLower(h.Key), Val: h.Val}
+		if strings.HasSuffix(sh.Key, "-bin") {
+			sh.Binary = true
+			d.BinaryMetadata = true
+			sh.Val = base64.StdEncoding.EncodeToString([]byte(h.Val))
+		}
+		d.Metadata = append(d.Metadata, sh)
+	}
+	return d, nil
+}
+
+// goString quotes s as a raw string literal if it can be
+func goString(s string) string {
+	if !strings.Contains(s, "`") {
+		return "`" + s + "`"
+	}
+	return strconv.Quote(s)
+}
+
+// pyString quotes s as a triple quoted Python string
+func pyString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"""`, `\"\"\"`)
+	return `"""` + s + `"""`
+}
+
+// indent indents every line of s after the first
+func indent(n int, s string) string {
+	return strings.ReplaceAll(s, "\n", "\n"+strings.Repeat(" ", n))
+}
+
+var snippetFuncs = template.FuncMap{
+	"goString": goString,
+	"pyString": pyString,
+	"quote":    strconv.Quote,
+	"indent":   indent,
+}
+
+const goSnippet = `package main
+
+import (
+	"context"
+{{- if not .Plaintext}}
+	"crypto/tls"
+{{- if .RootCA}}
+	"crypto/x509"
+{{- end}}
+{{- end}}
+{{- if .BinaryMetadata}}
+	"encoding/base64"
+{{- end}}
+{{- if or .ServerStream .ClientStream}}
+	"io"
+{{- end}}
+	"log"
+{{- if or .RootCA .Dynamic}}
+	"os"
+{{- end}}
+{{- if .TimeoutMs}}
+	"time"
+{{- end}}
+
+	"google.golang.org/grpc"
+{{- if .Plaintext}}
+	"google.golang.org/grpc/credentials/insecure"
+{{- else}}
+	"google.golang.org/grpc/credentials"
+{{- end}}
+{{- if .Metadata}}
+	"google.golang.org/grpc/metadata"
+{{- end}}
+	"google.golang.org/protobuf/encoding/protojson"
+{{- if .Dynamic}}
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+{{- else}}
+
+	{{.GoPackage}} "{{.GoImport}}"
+{{- if .GoInputImport}}
+	{{.GoInputPackage}} "{{.GoInputImport}}"
+{{- end}}
+{{- end}}
+)
+
+func main() {
+{{- if .Dynamic}}
+	// The protoset is the method's proto files and their imports, from
+	// protoc --include_imports --descriptor_set_out=service.protoset
+	b, err := os.ReadFile("service.protoset")
+	if err != nil {
+		log.Fatal(err)
+	}
+	var fds descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(b, &fds); err != nil {
+		log.Fatal(err)
+	}
+	files, err := protodesc.NewFiles(&fds)
+	if err != nil {
+		log.Fatal(err)
+	}
+	desc, err := files.FindDescriptorByName("{{.Service}}.{{.MethodName}}")
+	if err != nil {
+		log.Fatal(err)
+	}
+	md := desc.(protoreflect.MethodDescriptor)
+{{end}}
+{{- if .Plaintext}}
+	creds := insecure.NewCredentials()
+{{- else}}
+	tlsCfg := &tls.Config{
+{{- if .ServerName}}
+		ServerName: {{quote .ServerName}},
+{{- end}}
+{{- if .Insecure}}
+		InsecureSkipVerify: true,
+{{- end}}
+	}
+{{- if .RootCA}}
+	ca, err := os.ReadFile("ca.pem")
+	if err != nil {
+		log.Fatal(err)
+	}
+	tlsCfg.RootCAs = x509.NewCertPool()
+	tlsCfg.RootCAs.AppendCertsFromPEM(ca)
+{{- end}}
+{{- if .ClientCert}}
+	cert, err := tls.LoadX509KeyPair("client.pem", "client-key.pem")
+	if err != nil {
+		log.Fatal(err)
+	}
+	tlsCfg.Certificates = []tls.Certificate{cert}
+{{- end}}
+	creds := credentials.NewTLS(tlsCfg)
+{{- end}}
+
+	conn, err := grpc.NewClient({{quote .Addr}},
+		grpc.WithTransportCredentials(creds),
+{{- if .Authority}}
+		grpc.WithAuthority({{quote .Authority}}),
+{{- end}}
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer conn.Close()
+
+	ctx := context.Background()
+{{- range .Metadata}}
+{{- if .Binary}}
+	{
+		v, _ := base64.StdEncoding.DecodeString({{quote .Val}})
+		ctx = metadata.AppendToOutgoingContext(ctx, {{quote .Key}}, string(v))
+	}
+{{- else}}
+	ctx = metadata.AppendToOutgoingContext(ctx, {{quote .Key}}, {{quote .Val}})
+{{- end}}
+{{- end}}
+{{- if .TimeoutMs}}
+	ctx, cancel := context.WithTimeout(ctx, {{.TimeoutMs}}*time.Millisecond)
+	defer cancel()
+{{- end}}
+{{if .Dynamic}}
+	req := dynamicpb.NewMessage(md.Input())
+{{- else}}
+	req := &{{.GoInput}}{}
+{{- end}}
+	if err := protojson.Unmarshal([]byte({{indent 1 (goString .Body)}}), req); err != nil {
+		log.Fatal(err)
+	}
+
+{{- if .Dynamic}}
+{{- if or .ClientStream .ServerStream}}
+
+	sd := &grpc.StreamDesc{
+		ClientStreams: {{.ClientStream}},
+		ServerStreams: {{.ServerStream}},
+	}
+	stream, err := conn.NewStream(ctx, sd, {{quote .Method}})
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := stream.SendMsg(req); err != nil {
+		log.Fatal(err)
+	}
+	if err := stream.CloseSend(); err != nil {
+		log.Fatal(err)
+	}
+	for {
+		resp := dynamicpb.NewMessage(md.Output())
+		if err := stream.RecvMsg(resp); err == io.EOF {
+			break
+		} else if err != nil {
+			log.Fatal(err)
+		}
+		log.Println(protojson.Format(resp))
+	}
+{{- else}}
+
+	resp := dynamicpb.NewMessage(md.Output())
+	if err := conn.Invoke(ctx, {{quote .Method}}, req, resp); err != nil {
+		log.Fatal(err)
+	}
+	log.Println(protojson.Format(resp))
+{{- end}}
+{{- else}}
+
+	client := {{.GoPackage}}.New{{.GoService}}Client(conn)
+{{- if and .ClientStream .ServerStream}}
+	stream, err := client.{{.GoMethod}}(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := stream.Send(req); err != nil {
+		log.Fatal(err)
+	}
+	if err := stream.CloseSend(); err != nil {
+		log.Fatal(err)
+	}
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			log.Fatal(err)
+		}
+		log.Println(protojson.Format(resp))
+	}
+{{- else if .ClientStream}}
+	stream, err := client.{{.GoMethod}}(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := stream.Send(req); err != nil && err != io.EOF {
+		log.Fatal(err)
+	}
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Println(protojson.Format(resp))
+{{- else if .ServerStream}}
+	stream, err := client.{{.GoMethod}}(ctx, req)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			log.Fatal(err)
+		}
+		log.Println(protojson.Format(resp))
+	}
+{{- else}}
+	resp, err := client.{{.GoMethod}}(ctx, req)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Println(protojson.Format(resp))
+{{- end}}
+{{- end}}
+}
+`
+
+const pythonSnippet = `{{if .BinaryMetadata}}import base64
+
+{{end}}import grpc
+from google.protobuf import json_format
+
+import {{.PyModule}}_pb2
+import {{.PyModule}}_pb2_grpc
+
+{{if .Plaintext -}}
+channel = grpc.insecure_channel({{quote .Addr}}{{if .Authority}}, options=[("grpc.default_authority", {{quote .Authority}})]{{end}})
+{{- else -}}
+{{if .Insecure}}# grpcio can't skip verifying the server certificate, so the CA
+# that signed it has to be trusted
+{{end -}}
+credentials = grpc.ssl_channel_credentials(
+{{- if .RootCA}}
+    root_certificates=open("ca.pem", "rb").read(),
+{{- end}}
+{{- if .ClientCert}}
+    private_key=open("client-key.pem", "rb").read(),
+    certificate_chain=open("client.pem", "rb").read(),
+{{- end}}
+)
+channel = grpc.secure_channel({{quote .Addr}}, credentials{{if or .ServerName .Authority}}, options=[
+{{- if .ServerName}}
+    ("grpc.ssl_target_name_override", {{quote .ServerName}}),
+{{- end}}
+{{- if .Authority}}
+    ("grpc.default_authority", {{quote .Authority}}),
+{{- end}}
+]{{end}})
+{{- end}}
+stub = {{.PyModule}}_pb2_grpc.{{.ServiceName}}Stub(channel)
+
+metadata = [
+{{- range .Metadata}}
+    ({{quote .Key}}, {{if .Binary}}base64.b64decode({{quote .Val}}){{else}}{{quote .Val}}{{end}}),
+{{- end}}
+]
+request = json_format.Parse({{pyString .Body}}, {{.PyInput}}())
+{{$args := "metadata=metadata"}}{{if .TimeoutMs}}{{$args = printf "metadata=metadata, timeout=%g" .TimeoutSeconds}}{{end}}
+{{- if .ServerStream}}
+for response in stub.{{.MethodName}}({{if .ClientStream}}iter([request]){{else}}request{{end}}, {{$args}}):
+    print(json_format.MessageToJson(response))
+{{- else}}
+response = stub.{{.MethodName}}({{if .ClientStream}}iter([request]){{else}}request{{end}}, {{$args}})
+print(json_format.MessageToJson(response))
+{{- end}}
+`
+
+const nodeSnippet = `const grpc = require("@grpc/grpc-js");
+const protoLoader = require("@grpc/proto-loader");
+{{- if not .Plaintext}}
+const fs = require("fs");
+{{- end}}
+
+const definition = protoLoader.loadSync({{quote .ProtoFile}}, {
+  keepCase: true,
+  longs: String,
+  enums: String,
+  defaults: true,
+  oneofs: true,
+});
+const proto = grpc.loadPackageDefinition(definition);
+
+{{if .Plaintext -}}
+const credentials = grpc.credentials.createInsecure();
+{{- else -}}
+const credentials = grpc.credentials.createSsl(
+  {{if .RootCA}}fs.readFileSync("ca.pem"){{else}}null{{end}},
+  {{if .ClientCert}}fs.readFileSync("client-key.pem"){{else}}null{{end}},
+  {{if .ClientCert}}fs.readFileSync("client.pem"){{else}}null{{end}},
+{{- if .Insecure}}
+  { checkServerIdentity: () => undefined },
+{{- end}}
+);
+{{- end}}
+const client = new proto.{{.Service}}({{quote .Addr}}, credentials{{if or .ServerName .Authority}}, {
+{{- if .ServerName}}
+  "grpc.ssl_target_name_override": {{quote .ServerName}},
+{{- end}}
+{{- if .Authority}}
+  "grpc.default_authority": {{quote .Authority}},
+{{- end}}
+}{{end}});
+
+const metadata = new grpc.Metadata();
+{{- range .Metadata}}
+metadata.add({{quote .Key}}, {{if .Binary}}Buffer.from({{quote .Val}}, "base64"){{else}}{{quote .Val}}{{end}});
+{{- end}}
+const options = {{if .TimeoutMs}}{ deadline: Date.now() + {{.TimeoutMs}} }{{else}}{}{{end}};
+
+const request = {{.Body}};
+{{if and .ClientStream .ServerStream}}
+const call = client.{{.MethodName}}(metadata, options);
+call.on("data", (response) => console.log(JSON.stringify(response, null, 2)));
+call.on("error", (err) => console.error(err));
+call.write(request);
+call.end();
+{{- else if .ClientStream}}
+const call = client.{{.MethodName}}(metadata, options, (err, response) => {
+  if (err) {
+    console.error(err);
+    return;
+  }
+  console.log(JSON.stringify(response, null, 2));
+});
+call.write(request);
+call.end();
+{{- else if .ServerStream}}
+const call = client.{{.MethodName}}(request, metadata, options);
+call.on("data", (response) => console.log(JSON.stringify(response, null, 2)));
+call.on("error", (err) => console.error(err));
+{{- else}}
+client.{{.MethodName}}(request, metadata, options, (err, response) => {
+  if (err) {
+    console.error(err);
+    return;
+  }
+  console.log(JSON.stringify(response, null, 2));
+});
+{{- end}}
+`
+
+const javaSnippet = `{{if .JavaPackage}}package {{.JavaPackage}};
+
+{{end}}import com.google.protobuf.util.JsonFormat;
+import io.grpc.Grpc;
+{{- if .Plaintext}}
+import io.grpc.InsecureChannelCredentials;
+{{- else}}
+import io.grpc.TlsChannelCredentials;
+{{- end}}
+import io.grpc.ManagedChannel;
+import io.grpc.Metadata;
+import io.grpc.stub.MetadataUtils;
+{{- if .ClientStream}}
+import io.grpc.stub.StreamObserver;
+{{- end}}
+{{- if and (not .Plaintext) (or .RootCA .ClientCert)}}
+import java.io.File;
+{{- end}}
+{{- if .BinaryMetadata}}
+import java.util.Base64;
+{{- end}}
+{{- if .ClientStream}}
+import java.util.concurrent.CountDownLatch;
+{{- end}}
+{{- if .ServerStream}}
+import java.util.Iterator;
+{{- end}}
+import java.util.concurrent.TimeUnit;
+
+public class {{.MethodName}}Client {
+  public static void main(String[] args) throws Exception {
+{{- if .Plaintext}}
+    ManagedChannel channel = Grpc.newChannelBuilder({{quote .Addr}}, InsecureChannelCredentials.create())
+{{- else}}
+    TlsChannelCredentials.Builder tls = TlsChannelCredentials.newBuilder();
+{{- if .Insecure}}
+    tls.trustManager(io.grpc.netty.shaded.io.netty.handler.ssl.util.InsecureTrustManagerFactory.INSTANCE.getTrustManagers());
+{{- else if .RootCA}}
+    tls.trustManager(new File("ca.pem"));
+{{- end}}
+{{- if .ClientCert}}
+    tls.keyManager(new File("client.pem"), new File("client-key.pem"));
+{{- end}}
+    ManagedChannel channel = Grpc.newChannelBuilder({{quote .Addr}}, tls.build())
+{{- if .ServerName}}
+        .overrideAuthority({{quote .ServerName}})
+{{- else if .Authority}}
+        .overrideAuthority({{quote .Authority}})
+{{- end}}
+{{- end}}
+{{- if and .Plaintext .Authority}}
+        .overrideAuthority({{quote .Authority}})
+{{- end}}
+        .build();
+
+    Metadata metadata = new Metadata();
+{{- range .Metadata}}
+{{- if .Binary}}
+    metadata.put(Metadata.Key.of({{quote .Key}}, Metadata.BINARY_BYTE_MARSHALLER), Base64.getDecoder().decode({{quote .Val}}));
+{{- else}}
+    metadata.put(Metadata.Key.of({{quote .Key}}, Metadata.ASCII_STRING_MARSHALLER), {{quote .Val}});
+{{- end}}
+{{- end}}
+
+    {{.JavaInput}}.Builder request = {{.JavaInput}}.newBuilder();
+    JsonFormat.parser().merge({{quote .Body}}, request);
+{{if .ClientStream}}
+    CountDownLatch done = new CountDownLatch(1);
+    StreamObserver<{{.JavaOutput}}> responses = new StreamObserver<{{.JavaOutput}}>() {
+      @Override
+      public void onNext({{.JavaOutput}} response) {
+        try {
+          System.out.println(JsonFormat.printer().print(response));
+        } catch (Exception e) {
+          throw new RuntimeException(e);
+        }
+      }
+
+      @Override
+      public void onError(Throwable t) {
+        t.printStackTrace();
+        done.countDown();
+      }
+
+      @Override
+      public void onCompleted() {
+        done.countDown();
+      }
+    };
+    {{.JavaService}}.{{.ServiceName}}Stub stub = {{.JavaService}}.newStub(channel)
+        .withInterceptors(MetadataUtils.newAttachHeadersInterceptor(metadata)){{if .TimeoutMs}}
+        .withDeadlineAfter({{.TimeoutMs}}, TimeUnit.MILLISECONDS){{end}};
+    StreamObserver<{{.JavaInput}}> requests = stub.{{.JavaMethod}}(responses);
+    requests.onNext(request.build());
+    requests.onCompleted();
+    done.await();
+{{- else}}
+    {{.JavaService}}.{{.ServiceName}}BlockingStub stub = {{.JavaService}}.newBlockingStub(channel)
+        .withInterceptors(MetadataUtils.newAttachHeadersInterceptor(metadata)){{if .TimeoutMs}}
+        .withDeadlineAfter({{.TimeoutMs}}, TimeUnit.MILLISECONDS){{end}};
+{{- if .ServerStream}}
+    Iterator<{{.JavaOutput}}> responses = stub.{{.JavaMethod}}(request.build());
+    while (responses.hasNext()) {
+      System.out.println(JsonFormat.printer().print(responses.next()));
+    }
+{{- else}}
+    {{.JavaOutput}} response = stub.{{.JavaMethod}}(request.build());
+    System.out.println(JsonFormat.printer().print(response));
+{{- end}}
+{{- end}}
+
+    channel.shutdown().awaitTermination(5, TimeUnit.SECONDS);
+  }
+}
+`
+
+var goSnippetTmpl = template.Must(template.New("go").Funcs(snippetFuncs).Parse(goSnippet))
+
+var snippetTemplates = []struct {
+	language, name string
+	tmpl           *template.Template
+	dynamic        bool
+}{
+	{"go", "Go (generated code)", goSnippetTmpl, false},
+	{"go", "Go (dynamicpb)", goSnippetTmpl, true},
+	{"python", "Python", template.Must(template.New("python").Funcs(snippetFuncs).Parse(pythonSnippet)), false},
+	{"javascript", "Node", template.Must(template.New("node").Funcs(snippetFuncs).Parse(nodeSnippet)), false},
+	{"java", "Java", template.Must(template.New("java").Funcs(snippetFuncs).Parse(javaSnippet)), false},
+}
+
+// codeSnippets returns client code for the call in each language, using the
+// connection settings of the workspace
+func codeSnippets(opts options, md protoreflect.MethodDescriptor, rawJSON []byte, hs headers) ([]codeSnippet, error) {
+	if isHTTPTransport(opts.Transport) {
+		return nil, fmt.Errorf("code snippets are for grpc workspaces, not %s", opts.Transport)
+	}
+	d, err := newSnippetData(opts, md, rawJSON, hs)
+	if err != nil {
+		return nil, err
+	}
+
+	var snippets []codeSnippet
+	for _, st := range snippetTemplates {
+		d.Dynamic = st.dynamic
+		var buf bytes.Buffer
+		if err := st.tmpl.Execute(&buf, d); err != nil {
+			return nil, fmt.Errorf("failed to generate %s snippet: %v", st.name, err)
+		}
+		snippets = append(snippets, codeSnippet{
+			Language: st.language,
+			Name:     st.name,
+			Code:     buf.String(),
+		})
+	}
+	return snippets, nil
+}
@@ -68,6 +68,22 @@ func (s *store) list(prefix []byte) ([][]byte, error) {
 	return items, err
 }
 
+func (s *store) keys(prefix []byte) ([][]byte, error) {
+	var keys [][]byte
+	err := s.db.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.PrefetchValues = false
+		it := txn.NewIterator(opts)
+		defer it.Close()
+		for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+			keys = append(keys, it.Item().KeyCopy(nil))
+		}
+		return nil
+	})
+
+	return keys, err
+}
+
 func (s *store) close() {
 	if s == nil {
 		return
@@ -0,0 +1,166 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const maxTimelineEvents = 1000
+
+const (
+	connEventState     = "state"
+	connEventBegin     = "conn_begin"
+	connEventEnd       = "conn_end"
+	connEventHandshake = "handshake_error"
+)
+
+type connEvent struct {
+	Time       time.Time `json:"time"`
+	Kind       string    `json:"kind"`
+	State      string    `json:"state"`
+	Reason     string    `json:"reason"`
+	RemoteAddr string    `json:"remote_addr"`
+	LocalAddr  string    `json:"local_addr"`
+}
+
+// connTimeline is a persisted, per workspace record of connectivity
+// transitions and the transport events that caused them. Each event is
+// stored under its own key, so recording one doesn't rewrite the others.
+type connTimeline struct {
+	ctx    context.Context
+	mu     sync.Mutex
+	store  *store
+	prefix string
+	keys   [][]byte
+	events []connEvent
+	seq    int64  // the last event key, which orders events at the same time
+	reason string // last transport event, used to explain the next state change
+	emit   func(connEvent)
+}
+
+func newConnTimeline(ctx context.Context, s *store, id string, emit func(connEvent)) *connTimeline {
+	t := &connTimeline{
+		ctx:    ctx,
+		store:  s,
+		prefix: timelineKeyPrefix + hash(id) + "_",
+		emit:   emit,
+	}
+	if s == nil {
+		return t
+	}
+	keys, err := s.keys([]byte(t.prefix))
+	if err != nil {
+		return t
+	}
+	vals, err := s.list([]byte(t.prefix))
+	if err != nil || len(vals) != len(keys) {
+		return t
+	}
+	for i, val := range vals {
+		var ev connEvent
+		if err := gob.NewDecoder(bytes.NewBuffer(val)).Decode(&ev); err != nil {
+			continue
+		}
+		t.keys = append(t.keys, keys[i])
+		t.events = append(t.events, ev)
+	}
+	if n := len(t.keys); n > 0 {
+		fmt.Sscanf(strings.TrimPrefix(string(t.keys[n-1]), t.prefix), "%d", &t.seq)
+	}
+	return t
+}
+
+func (t *connTimeline) add(ev connEvent) {
+	if t == nil {
+		return
+	}
+	if ev.Time.IsZero() {
+		ev.Time = time.Now()
+	}
+
+	t.mu.Lock()
+	if ev.Kind == connEventState {
+		if ev.Reason == "" {
+			ev.Reason = t.reason
+		}
+		t.reason = ""
+	} else {
+		t.reason = ev.Reason
+	}
+	err := t.persist(ev)
+	t.mu.Unlock()
+
+	if err != nil {
+		runtime.LogError(t.ctx, fmt.Sprintf("failed to store connection timeline: %v", err))
+	}
+	if t.emit != nil {
+		t.emit(ev)
+	}
+}
+
+func (t *connTimeline) list() []connEvent {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]connEvent(nil), t.events...)
+}
+
+func (t *connTimeline) clear() error {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.events = nil
+	t.reason = ""
+	if t.store == nil {
+		t.keys = nil
+		return nil
+	}
+	// Events recorded by another timeline of the workspace are removed too
+	keys, err := t.store.keys([]byte(t.prefix))
+	if err != nil {
+		return err
+	}
+	t.keys = nil
+	for _, key := range keys {
+		if err := t.store.del(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// persist must be called with t.mu held. It stores the event, and removes
+// the oldest once there are more than maxTimelineEvents.
+func (t *connTimeline) persist(ev connEvent) error {
+	t.seq = max(t.seq+1, ev.Time.UnixNano())
+	key := []byte(fmt.Sprintf("%s%020d", t.prefix, t.seq))
+	t.keys = append(t.keys, key)
+	t.events = append(t.events, ev)
+
+	var err error
+	if t.store != nil {
+		var val bytes.Buffer
+		if err = gob.NewEncoder(&val).Encode(ev); err == nil {
+			err = t.store.set(key, val.Bytes())
+		}
+	}
+	for len(t.events) > maxTimelineEvents {
+		if t.store != nil {
+			t.store.del(t.keys[0])
+		}
+		t.keys = t.keys[1:]
+		t.events = t.events[1:]
+	}
+	return err
+}
@@ -0,0 +1,350 @@
+package app
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	transportGRPC        = "grpc"
+	transportGRPCWeb     = "grpc-web"
+	transportGRPCWebText = "grpc-web-text"
+	transportConnect     = "connect"
+)
+
+const (
+	codecProto = "proto"
+	codecJSON  = "json"
+)
+
+// httpTransport is implemented by transports that send calls with net/http
+// instead of a grpc.ClientConn.
+type httpTransport interface {
+	invoke(ctx context.Context, method string, req, resp proto.Message) error
+	newStream(ctx context.Context, desc *grpc.StreamDesc, method string) (grpc.ClientStream, error)
+}
+
+// isHTTPTransport reports if the transport sends requests with net/http
+// rather than a grpc.ClientConn
+func isHTTPTransport(t string) bool {
+	switch t {
+	case transportGRPCWeb, transportGRPCWebText, transportConnect:
+		return true
+	}
+	return false
+}
+
+func newHTTPTransport(o options, h stats.Handler) (httpTransport, error) {
+	if o.Transport == transportConnect {
+		return newConnectClient(o, h)
+	}
+	return newWebClient(o, h)
+}
+
+func validateTransport(o options) error {
+	switch o.Codec {
+	case "", codecProto, codecJSON:
+	default:
+		return fmt.Errorf("unsupported codec: %q", o.Codec)
+	}
+
+	switch o.Transport {
+	case "", transportGRPC:
+		return nil
+	case transportGRPCWeb, transportGRPCWebText, transportConnect:
+		if o.Reflect {
+			return fmt.Errorf("server reflection is not available over %s, use proto files instead", o.Transport)
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported transport: %q", o.Transport)
+}
+
+const envelopeHeaderLen = 5
+
+// writeEnvelope writes a length-prefixed message as used by both grpc-web
+// and the streaming Connect protocol.
+func writeEnvelope(w io.Writer, flags byte, data []byte) error {
+	var hdr [envelopeHeaderLen]byte
+	hdr[0] = flags
+	binary.BigEndian.PutUint32(hdr[1:], uint32(len(data)))
+	if _, err := w.Write(hdr[:]); err != nil {
+		return err
+	}
+	_, err := w.Write(data)
+	return err
+}
+
+// readEnvelope reads a single length-prefixed message. It returns io.EOF only
+// if no bytes were read.
+func readEnvelope(r io.Reader) (flags byte, data []byte, err error) {
+	var hdr [envelopeHeaderLen]byte
+	if _, err := io.ReadFull(r, hdr[:]); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return 0, nil, errors.New("truncated message envelope")
+		}
+		return 0, nil, err
+	}
+	data = make([]byte, binary.BigEndian.Uint32(hdr[1:]))
+	if _, err := io.ReadFull(r, data); err != nil {
+		return 0, nil, fmt.Errorf("truncated message: %v", err)
+	}
+	return hdr[0], data, nil
+}
+
+// base64Reader decodes a stream of base64 chunks. Servers are allowed to
+// pad each chunk they flush, so the stream is decoded one quantum at a time.
+type base64Reader struct {
+	r   io.Reader
+	in  []byte
+	out []byte
+	err error
+}
+
+func (b *base64Reader) Read(p []byte) (int, error) {
+	for len(b.out) == 0 {
+		if b.err != nil {
+			return 0, b.err
+		}
+		buf := make([]byte, 4096)
+		n, err := b.r.Read(buf)
+		for _, c := range buf[:n] {
+			if c == '\r' || c == '\n' {
+				continue
+			}
+			b.in = append(b.in, c)
+		}
+		for len(b.in) >= 4 {
+			var q [3]byte
+			m, derr := base64.StdEncoding.Decode(q[:], b.in[:4])
+			if derr != nil {
+				return 0, fmt.Errorf("invalid grpc-web-text response: %v", derr)
+			}
+			b.out = append(b.out, q[:m]...)
+			b.in = b.in[4:]
+		}
+		if err != nil {
+			if err == io.EOF && len(b.in) > 0 {
+				err = io.ErrUnexpectedEOF
+			}
+			b.err = err
+		}
+	}
+	n := copy(p, b.out)
+	b.out = b.out[n:]
+	return n, nil
+}
+
+// httpBaseURL returns the URL that method paths are appended to. The addr
+// may already be a URL, possibly with a path prefix for proxies.
+func httpBaseURL(o options) (string, error) {
+	addr := strings.TrimSuffix(o.Addr, "/")
+	if addr == "" {
+		return "", errors.New("no address provided")
+	}
+	if !strings.Contains(addr, "://") {
+		scheme := "https"
+		if o.Plaintext {
+			scheme = "http"
+		}
+		addr = scheme + "://" + addr
+	}
+	u, err := url.Parse(addr)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("unsupported scheme %q", u.Scheme)
+	}
+	return u.String(), nil
+}
+
+func newHTTPClient(o options) (*http.Client, error) {
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	if !o.Plaintext {
+		tlsCfg, err := tlsConfig(o)
+		if err != nil {
+			return nil, err
+		}
+		tr.TLSClientConfig = tlsCfg
+	}
+	return &http.Client{Transport: tr}, nil
+}
+
+// setRequestMetadata copies the outgoing metadata of ctx onto the request
+func setRequestMetadata(ctx context.Context, h http.Header) metadata.MD {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	for k, vs := range md {
+		for _, v := range vs {
+			if strings.HasSuffix(k, "-bin") {
+				v = base64.StdEncoding.EncodeToString([]byte(v))
+			}
+			h.Add(k, v)
+		}
+	}
+	return md
+}
+
+// metadataFromHeader converts HTTP headers, or grpc-web trailers, to metadata
+func metadataFromHeader(h http.Header) metadata.MD {
+	md := metadata.MD{}
+	for k, vs := range h {
+		k = strings.ToLower(k)
+		for _, v := range vs {
+			if strings.HasSuffix(k, "-bin") {
+				if b, err := decodeBinHeader(v); err == nil {
+					v = string(b)
+				}
+			}
+			md.Append(k, v)
+		}
+	}
+	return md
+}
+
+func decodeBinHeader(v string) ([]byte, error) {
+	if len(v)%4 == 0 {
+		return base64.StdEncoding.DecodeString(v)
+	}
+	return base64.RawStdEncoding.DecodeString(v)
+}
+
+// encodeTimeout formats a deadline as a grpc-timeout header value
+func encodeTimeout(d time.Duration) string {
+	if d <= 0 {
+		return "1n"
+	}
+	ms := d.Milliseconds()
+	if ms < 1e8 {
+		return fmt.Sprintf("%dm", ms+1)
+	}
+	return fmt.Sprintf("%dS", int64(d.Seconds())+1)
+}
+
+// statusFromHTTP maps an HTTP response status to a grpc status, as
+// described in the grpc http-grpc-status-mapping doc
+func statusFromHTTP(code int, msg string) *status.Status {
+	c := codes.Unknown
+	switch code {
+	case http.StatusBadRequest:
+		c = codes.Internal
+	case http.StatusUnauthorized:
+		c = codes.Unauthenticated
+	case http.StatusForbidden:
+		c = codes.PermissionDenied
+	case http.StatusNotFound:
+		c = codes.Unimplemented
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		c = codes.Unavailable
+	}
+	if msg == "" {
+		msg = fmt.Sprintf("unexpected HTTP status code: %d %s", code, http.StatusText(code))
+	}
+	return status.New(c, msg)
+}
+
+// httpStats reports the lifecycle of a call made over net/http to a
+// stats.Handler, so the frontend receives the same events as for calls
+// made with a grpc.ClientConn.
+type httpStats struct {
+	ctx    context.Context
+	h      stats.Handler
+	method string
+	begin  time.Time
+	ended  bool
+}
+
+func newHTTPStats(ctx context.Context, h stats.Handler, method string, clientStream, serverStream bool) (context.Context, *httpStats) {
+	s := &httpStats{h: h, method: method, begin: time.Now()}
+	if h == nil {
+		s.ctx = ctx
+		return ctx, s
+	}
+	s.ctx = h.TagRPC(ctx, &stats.RPCTagInfo{FullMethodName: method})
+	h.HandleRPC(s.ctx, &stats.Begin{
+		Client:         true,
+		BeginTime:      s.begin,
+		IsClientStream: clientStream,
+		IsServerStream: serverStream,
+	})
+	return s.ctx, s
+}
+
+func (s *httpStats) handle(stat stats.RPCStats) {
+	if s.h == nil || s.ended {
+		return
+	}
+	s.h.HandleRPC(s.ctx, stat)
+}
+
+func (s *httpStats) outHeader(md metadata.MD) {
+	s.handle(&stats.OutHeader{
+		Client:     true,
+		FullMethod: s.method,
+		Header:     md.Copy(),
+	})
+}
+
+func (s *httpStats) outPayload(m proto.Message, data []byte, wireLen int) {
+	s.handle(&stats.OutPayload{
+		Client:           true,
+		Payload:          m,
+		Length:           len(data),
+		CompressedLength: len(data),
+		WireLength:       wireLen,
+		SentTime:         time.Now(),
+	})
+}
+
+func (s *httpStats) inHeader(md metadata.MD, wireLen int) {
+	s.handle(&stats.InHeader{
+		Client:     true,
+		FullMethod: s.method,
+		Header:     md,
+		WireLength: wireLen,
+	})
+}
+
+func (s *httpStats) inPayload(m proto.Message, data []byte, wireLen int) {
+	s.handle(&stats.InPayload{
+		Client:           true,
+		Payload:          m,
+		Length:           len(data),
+		CompressedLength: len(data),
+		WireLength:       wireLen,
+		RecvTime:         time.Now(),
+	})
+}
+
+func (s *httpStats) inTrailer(md metadata.MD) {
+	s.handle(&stats.InTrailer{
+		Client:  true,
+		Trailer: md,
+	})
+}
+
+func (s *httpStats) end(trailer metadata.MD, err error) {
+	s.handle(&stats.End{
+		Client:    true,
+		BeginTime: s.begin,
+		EndTime:   time.Now(),
+		Trailer:   trailer,
+		Error:     err,
+	})
+	s.ended = true
+}
@@ -0,0 +1,748 @@
+package app
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+const bufValidateMessage = "buf.validate.message"
+
+// Values of buf.validate.Ignore
+const (
+	ignoreIfUnpopulated  = 1
+	ignoreIfDefaultValue = 2
+	ignoreAlways         = 3
+)
+
+var (
+	uuidPattern  = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	tuuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{32}$`)
+	hostLabel    = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?$`)
+)
+
+// fieldViolation is a request field that breaks a validation rule. Field is
+// the path of the field with proto names, such as user.tags[0] or
+// labels["env"], and Rule the rule it breaks, such as string.min_len.
+type fieldViolation struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// errInvalidRequest is returned when a request breaks the rules of its fields
+type errInvalidRequest []fieldViolation
+
+func (e errInvalidRequest) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, v := range e {
+		msgs = append(msgs, v.Field+": "+v.Message)
+	}
+	return "invalid request: " + strings.Join(msgs, "; ")
+}
+
+// validator checks messages against the buf.validate and protoc-gen-validate
+// rules of their fields. Only the standard rules are checked; CEL expressions
+// are left to the server.
+type validator struct {
+	types *dynamicpb.Types
+
+	mu sync.Mutex // protects rules
+	// rules holds the rule option of each field, oneof and message by full
+	// name, invalid if it has none
+	rules map[protoreflect.FullName]protoreflect.Value
+}
+
+func newValidator(files *protoregistry.Files) *validator {
+	return &validator{
+		types: dynamicpb.NewTypes(files),
+		rules: make(map[protoreflect.FullName]protoreflect.Value),
+	}
+}
+
+// rulesOf returns the first of the options with the names set on the
+// descriptor
+func (v *validator) rulesOf(d protoreflect.Descriptor, names ...protoreflect.FullName) protoreflect.Value {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if r, ok := v.rules[d.FullName()]; ok {
+		return r
+	}
+	var r protoreflect.Value
+	for _, name := range names {
+		if val, ok := optionExtension(d, name, v.types); ok {
+			r = val
+			break
+		}
+	}
+	v.rules[d.FullName()] = r
+	return r
+}
+
+// messageRules returns the rules of the descriptor that are a message, or
+// nil if it has none
+func (v *validator) messageRules(d protoreflect.Descriptor, names ...protoreflect.FullName) protoreflect.Message {
+	r := v.rulesOf(d, names...)
+	if _, ok := r.Interface().(protoreflect.Message); !ok {
+		return nil
+	}
+	return r.Message()
+}
+
+// oneofRequired reports if a field of the oneof must be set. The rule of
+// protoc-gen-validate is a bool rather than a message.
+func (v *validator) oneofRequired(od protoreflect.OneofDescriptor) bool {
+	switch r := v.rulesOf(od, bufValidateOneof, pgvRequired).Interface().(type) {
+	case protoreflect.Message:
+		return ruleBool(r, "required")
+	case bool:
+		return r
+	}
+	return false
+}
+
+// validate returns the violations of the message, in field order
+func (v *validator) validate(m protoreflect.Message) []fieldViolation {
+	var vs []fieldViolation
+	v.message(m, "", &vs)
+	return vs
+}
+
+// ruleBool returns the bool rule with the name, false if it isn't set
+func ruleBool(r protoreflect.Message, name protoreflect.Name) bool {
+	if r == nil {
+		return false
+	}
+	fd := r.Descriptor().Fields().ByName(name)
+	return fd != nil && fd.Kind() == protoreflect.BoolKind && r.Get(fd).Bool()
+}
+
+// ruleMessage returns the rules of the message field with the name, or nil
+func ruleMessage(r protoreflect.Message, name protoreflect.Name) protoreflect.Message {
+	if r == nil {
+		return nil
+	}
+	fd := r.Descriptor().Fields().ByName(name)
+	if fd == nil || fd.Message() == nil || !r.Has(fd) {
+		return nil
+	}
+	return r.Get(fd).Message()
+}
+
+// typeRules returns the rules of the value type, such as string or int32,
+// set on the field rules
+func typeRules(r protoreflect.Message) (string, protoreflect.Message) {
+	if r == nil {
+		return "", nil
+	}
+	var name string
+	var rules protoreflect.Message
+	r.Range(func(fd protoreflect.FieldDescriptor, val protoreflect.Value) bool {
+		if fd.Message() != nil && fd.ContainingOneof() != nil && !fd.ContainingOneof().IsSynthetic() {
+			name, rules = string(fd.Name()), val.Message()
+			return false
+		}
+		return true
+	})
+	return name, rules
+}
+
+func (v *validator) message(m protoreflect.Message, path string, vs *[]fieldViolation) {
+	md := m.Descriptor()
+	if ruleBool(v.messageRules(md, bufValidateMessage), "disabled") {
+		return
+	}
+
+	oneofs := md.Oneofs()
+	for i := 0; i < oneofs.Len(); i++ {
+		od := oneofs.Get(i)
+		if od.IsSynthetic() || m.WhichOneof(od) != nil {
+			continue
+		}
+		if v.oneofRequired(od) {
+			*vs = append(*vs, fieldViolation{
+				Field:   strings.TrimPrefix(path+"."+string(od.Name()), "."),
+				Rule:    "required",
+				Message: "exactly one field is required in oneof",
+			})
+		}
+	}
+
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		v.field(m, fd, v.messageRules(fd, bufValidateField, pgvRules), joinPath(path, fd), vs)
+	}
+}
+
+func (v *validator) field(m protoreflect.Message, fd protoreflect.FieldDescriptor, r protoreflect.Message, path string, vs *[]fieldViolation) {
+	populated := m.Has(fd)
+	kind, tr := typeRules(r)
+
+	if r != nil {
+		ignore := protoreflect.EnumNumber(0)
+		if ifd := r.Descriptor().Fields().ByName("ignore"); ifd != nil && ifd.Kind() == protoreflect.EnumKind {
+			ignore = r.Get(ifd).Enum()
+		}
+		switch {
+		case ignore == ignoreAlways, ruleBool(r, "skipped"), ruleBool(ruleMessage(r, "message"), "skip"):
+			return
+		case !populated && (ignore == ignoreIfUnpopulated || ignore == ignoreIfDefaultValue ||
+			ruleBool(r, "ignore_empty") || ruleBool(tr, "ignore_empty")):
+			return
+		}
+		if !populated && (ruleBool(r, "required") || ruleBool(ruleMessage(r, "message"), "required")) {
+			*vs = append(*vs, fieldViolation{Field: path, Rule: "required", Message: "value is required"})
+			return
+		}
+	}
+	// Rules apply to the zero values of fields without presence only
+	if !populated && (fd.HasPresence() || fd.IsList() || fd.IsMap()) && kind != "repeated" && kind != "map" {
+		return
+	}
+
+	switch {
+	case fd.IsMap():
+		mp := m.Get(fd).Map()
+		if kind == "map" {
+			v.count(mp.Len(), "map", "pairs", tr, path, vs)
+		}
+		kr, vr := ruleMessage(tr, "keys"), ruleMessage(tr, "values")
+		mp.Range(func(k protoreflect.MapKey, val protoreflect.Value) bool {
+			p := path + "[" + k.String() + "]"
+			if fd.MapKey().Kind() == protoreflect.StringKind {
+				p = path + "[" + strconv.Quote(k.String()) + "]"
+			}
+			v.value(fd.MapKey(), k.Value(), kr, p, vs)
+			v.value(fd.MapValue(), val, vr, p, vs)
+			return true
+		})
+	case fd.IsList():
+		l := m.Get(fd).List()
+		ir := ruleMessage(tr, "items")
+		if kind == "repeated" {
+			v.count(l.Len(), "repeated", "items", tr, path, vs)
+			if ruleBool(tr, "unique") && !uniqueList(l) {
+				*vs = append(*vs, fieldViolation{Field: path, Rule: "repeated.unique", Message: "repeated value must contain unique items"})
+			}
+		}
+		for i := 0; i < l.Len(); i++ {
+			v.value(fd, l.Get(i), ir, fmt.Sprintf("%s[%d]", path, i), vs)
+		}
+	default:
+		v.value(fd, m.Get(fd), r, path, vs)
+	}
+}
+
+// count checks the min and max rules of the number of items of a list or
+// pairs of a map
+func (v *validator) count(n int, kind, unit string, tr protoreflect.Message, path string, vs *[]fieldViolation) {
+	fields := tr.Descriptor().Fields()
+	if fd := fields.ByName(protoreflect.Name("min_" + unit)); fd != nil && tr.Has(fd) && uint64(n) < tr.Get(fd).Uint() {
+		*vs = append(*vs, fieldViolation{
+			Field:   path,
+			Rule:    kind + ".min_" + unit,
+			Message: fmt.Sprintf("value must contain at least %d %s", tr.Get(fd).Uint(), unit),
+		})
+	}
+	if fd := fields.ByName(protoreflect.Name("max_" + unit)); fd != nil && tr.Has(fd) && uint64(n) > tr.Get(fd).Uint() {
+		*vs = append(*vs, fieldViolation{
+			Field:   path,
+			Rule:    kind + ".max_" + unit,
+			Message: fmt.Sprintf("value must contain no more than %d %s", tr.Get(fd).Uint(), unit),
+		})
+	}
+}
+
+func uniqueList(l protoreflect.List) bool {
+	seen := make(map[interface{}]bool)
+	for i := 0; i < l.Len(); i++ {
+		key := l.Get(i).Interface()
+		switch k := key.(type) {
+		case []byte:
+			key = string(k)
+		case protoreflect.Message:
+			// Messages are compared by their encoding
+			b, _ := protojson.Marshal(k.Interface())
+			key = string(b)
+		}
+		if seen[key] {
+			return false
+		}
+		seen[key] = true
+	}
+	return true
+}
+
+// value checks a single value of the field against its rules
+func (v *validator) value(fd protoreflect.FieldDescriptor, val protoreflect.Value, r protoreflect.Message, path string, vs *[]fieldViolation) {
+	kind, tr := typeRules(r)
+	md := fd.Message()
+	if md == nil {
+		if tr != nil {
+			v.scalar(fd, val, kind, tr, path, vs)
+		}
+		return
+	}
+
+	m := val.Message()
+	if md.ParentFile().Package() == "google.protobuf" && tr != nil {
+		switch md.Name() {
+		case "Duration", "Timestamp":
+			v.time(m, kind, tr, path, vs)
+			return
+		case "DoubleValue", "FloatValue", "Int64Value", "UInt64Value", "Int32Value", "UInt32Value",
+			"BoolValue", "StringValue", "BytesValue":
+			// Wrappers have the rules of the value they wrap
+			inner := md.Fields().ByName("value")
+			v.scalar(inner, m.Get(inner), kind, tr, path, vs)
+			return
+		}
+	}
+	v.message(m, path, vs)
+}
+
+// compareValues compares two values of the kind
+func compareValues(kind protoreflect.Kind, a, b protoreflect.Value) int {
+	switch kind {
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return cmp.Compare(a.Int(), b.Int())
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return cmp.Compare(a.Uint(), b.Uint())
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return cmp.Compare(a.Float(), b.Float())
+	}
+	return 0
+}
+
+// scalar checks the value against the rules of its type, which are expected
+// to be those of the kind of the field as protoc-gen-validate and buf lint
+// require
+func (v *validator) scalar(fd protoreflect.FieldDescriptor, val protoreflect.Value, kind string, tr protoreflect.Message, path string, vs *[]fieldViolation) {
+	add := func(rule, msg string, args ...interface{}) {
+		*vs = append(*vs, fieldViolation{Field: path, Rule: kind + "." + rule, Message: fmt.Sprintf(msg, args...)})
+	}
+	// equal compares the value with a rule value, which has the same Go type
+	// if the rules are for the type of the field
+	equal := func(rv protoreflect.Value) bool {
+		if b, ok := val.Interface().([]byte); ok {
+			rb, _ := rv.Interface().([]byte)
+			return string(b) == string(rb)
+		}
+		if fd.Kind() == protoreflect.EnumKind {
+			return int64(val.Enum()) == rv.Int()
+		}
+		return val.Interface() == rv.Interface()
+	}
+
+	var lower, upper protoreflect.FieldDescriptor
+	tr.Range(func(rfd protoreflect.FieldDescriptor, rv protoreflect.Value) bool {
+		name := rfd.Name()
+		switch name {
+		case "const":
+			if !equal(rv) {
+				add("const", "value must equal %s", formatValue(rfd, rv))
+			}
+		case "in", "not_in":
+			l := rv.List()
+			found := false
+			for i := 0; i < l.Len() && !found; i++ {
+				found = equal(l.Get(i))
+			}
+			if found != (name == "in") {
+				var vals []string
+				for i := 0; i < l.Len(); i++ {
+					vals = append(vals, formatValue(rfd, l.Get(i)))
+				}
+				if name == "in" {
+					add("in", "value must be in list [%s]", strings.Join(vals, ", "))
+				} else {
+					add("not_in", "value must not be in list [%s]", strings.Join(vals, ", "))
+				}
+			}
+		case "gt", "gte":
+			lower = rfd
+		case "lt", "lte":
+			upper = rfd
+		case "finite":
+			if rv.Bool() && (math.IsNaN(val.Float()) || math.IsInf(val.Float(), 0)) {
+				add("finite", "value must be finite")
+			}
+		case "defined_only":
+			if rv.Bool() && fd.Enum() != nil && fd.Enum().Values().ByNumber(val.Enum()) == nil {
+				add("defined_only", "value must be one of the defined enum values")
+			}
+		default:
+			if msg := stringRule(name, rv, val); msg != "" {
+				add(string(name), "%s", msg)
+			}
+		}
+		return true
+	})
+	if lower != nil || upper != nil {
+		if msg := rangeRule(fd.Kind(), val, tr, lower, upper); msg != "" {
+			rule := ""
+			for _, b := range []protoreflect.FieldDescriptor{lower, upper} {
+				if b != nil {
+					rule += "_" + string(b.Name())
+				}
+			}
+			add(rule[1:], "%s", msg)
+		}
+	}
+}
+
+// rangeRule checks the bounds of a number. If the lower bound is above the
+// upper one, the range excludes the values between them.
+func rangeRule(kind protoreflect.Kind, val protoreflect.Value, tr protoreflect.Message, lower, upper protoreflect.FieldDescriptor) string {
+	aboveLower, belowUpper := true, true
+	var lowerMsg, upperMsg string
+	if lower != nil {
+		c := compareValues(kind, val, tr.Get(lower))
+		aboveLower = c > 0 || (c == 0 && lower.Name() == "gte")
+		lowerMsg = "greater than " + tr.Get(lower).String()
+		if lower.Name() == "gte" {
+			lowerMsg = "greater than or equal to " + tr.Get(lower).String()
+		}
+	}
+	if upper != nil {
+		c := compareValues(kind, val, tr.Get(upper))
+		belowUpper = c < 0 || (c == 0 && upper.Name() == "lte")
+		upperMsg = "less than " + tr.Get(upper).String()
+		if upper.Name() == "lte" {
+			upperMsg = "less than or equal to " + tr.Get(upper).String()
+		}
+	}
+	switch {
+	case lower == nil:
+		if !belowUpper {
+			return "value must be " + upperMsg
+		}
+	case upper == nil:
+		if !aboveLower {
+			return "value must be " + lowerMsg
+		}
+	case compareValues(kind, tr.Get(lower), tr.Get(upper)) < 0:
+		if !aboveLower || !belowUpper {
+			return "value must be " + lowerMsg + " and " + upperMsg
+		}
+	default:
+		if !aboveLower && !belowUpper {
+			return "value must be " + lowerMsg + " or " + upperMsg
+		}
+	}
+	return ""
+}
+
+// stringRule checks the rules of strings and bytes, returning why the value
+// breaks the rule or an empty string
+func stringRule(name protoreflect.Name, rv, val protoreflect.Value) string {
+	var s string
+	isBytes := false
+	switch x := val.Interface().(type) {
+	case string:
+		s = x
+	case []byte:
+		s, isBytes = string(x), true
+	default:
+		return ""
+	}
+	n := utf8.RuneCountInString(s)
+	if isBytes {
+		n = len(s)
+	}
+
+	switch name {
+	case "len":
+		if uint64(n) != rv.Uint() {
+			return fmt.Sprintf("value length must be %d characters", rv.Uint())
+		}
+	case "min_len":
+		if uint64(n) < rv.Uint() {
+			return fmt.Sprintf("value length must be at least %d characters", rv.Uint())
+		}
+	case "max_len":
+		if uint64(n) > rv.Uint() {
+			return fmt.Sprintf("value length must be at most %d characters", rv.Uint())
+		}
+	case "len_bytes":
+		if uint64(len(s)) != rv.Uint() {
+			return fmt.Sprintf("value length must be %d bytes", rv.Uint())
+		}
+	case "min_bytes":
+		if uint64(len(s)) < rv.Uint() {
+			return fmt.Sprintf("value length must be at least %d bytes", rv.Uint())
+		}
+	case "max_bytes":
+		if uint64(len(s)) > rv.Uint() {
+			return fmt.Sprintf("value length must be at most %d bytes", rv.Uint())
+		}
+	case "pattern":
+		re, err := regexp.Compile(rv.String())
+		if err == nil && !re.MatchString(s) {
+			return fmt.Sprintf("value does not match regex pattern %q", rv.String())
+		}
+	case "prefix", "suffix", "contains", "not_contains":
+		sub := string(rv.Bytes())
+		if str, ok := rv.Interface().(string); ok {
+			sub = str
+		}
+		switch {
+		case name == "prefix" && !strings.HasPrefix(s, sub):
+			return fmt.Sprintf("value does not have prefix %q", sub)
+		case name == "suffix" && !strings.HasSuffix(s, sub):
+			return fmt.Sprintf("value does not have suffix %q", sub)
+		case name == "contains" && !strings.Contains(s, sub):
+			return fmt.Sprintf("value does not contain substring %q", sub)
+		case name == "not_contains" && strings.Contains(s, sub):
+			return fmt.Sprintf("value contains substring %q", sub)
+		}
+	case "email", "hostname", "ip", "ipv4", "ipv6", "uri", "uri_ref", "uuid", "tuuid", "address", "host_and_port":
+		if rv.Interface() != true {
+			return ""
+		}
+		if isBytes {
+			// Bytes hold the binary form of addresses
+			switch {
+			case name == "ip" && len(s) != net.IPv4len && len(s) != net.IPv6len:
+				return "value must be a valid IP address"
+			case name == "ipv4" && len(s) != net.IPv4len:
+				return "value must be a valid IPv4 address"
+			case name == "ipv6" && len(s) != net.IPv6len:
+				return "value must be a valid IPv6 address"
+			}
+			return ""
+		}
+		if !wellKnownString(string(name), s) {
+			return "value must be a valid " + wellKnownNames[string(name)]
+		}
+	}
+	return ""
+}
+
+var wellKnownNames = map[string]string{
+	"email":         "email address",
+	"hostname":      "hostname",
+	"ip":            "IP address",
+	"ipv4":          "IPv4 address",
+	"ipv6":          "IPv6 address",
+	"uri":           "URI",
+	"uri_ref":       "URI reference",
+	"uuid":          "UUID",
+	"tuuid":         "trimmed UUID",
+	"address":       "hostname or IP address",
+	"host_and_port": "host and port pair",
+}
+
+func isHostname(s string) bool {
+	s = strings.TrimSuffix(s, ".")
+	if s == "" || len(s) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(s, ".") {
+		if !hostLabel.MatchString(label) {
+			return false
+		}
+	}
+	return true
+}
+
+// wellKnownString reports if the string has the well known format
+func wellKnownString(format, s string) bool {
+	switch format {
+	case "email":
+		addr, err := mail.ParseAddress(s)
+		return err == nil && addr.Address == s && addr.Name == ""
+	case "hostname":
+		return isHostname(s)
+	case "ip":
+		return net.ParseIP(s) != nil
+	case "ipv4":
+		ip := net.ParseIP(s)
+		return ip != nil && ip.To4() != nil && !strings.Contains(s, ":")
+	case "ipv6":
+		return net.ParseIP(s) != nil && strings.Contains(s, ":")
+	case "uri":
+		u, err := url.Parse(s)
+		return err == nil && u.IsAbs()
+	case "uri_ref":
+		_, err := url.Parse(s)
+		return err == nil
+	case "uuid":
+		return uuidPattern.MatchString(s)
+	case "tuuid":
+		return tuuidPattern.MatchString(s)
+	case "address":
+		return isHostname(s) || net.ParseIP(s) != nil
+	case "host_and_port":
+		host, port, err := net.SplitHostPort(s)
+		if err != nil {
+			return false
+		}
+		_, err = strconv.ParseUint(port, 10, 16)
+		return err == nil && (isHostname(host) || net.ParseIP(host) != nil)
+	}
+	return true
+}
+
+// instant is a Duration, or a Timestamp as the time since the epoch. The
+// range of both is too wide for a time.Duration.
+type instant struct {
+	seconds int64
+	nanos   int64
+}
+
+func instantOf(m protoreflect.Message) instant {
+	fields := m.Descriptor().Fields()
+	return instant{m.Get(fields.ByName("seconds")).Int(), m.Get(fields.ByName("nanos")).Int()}
+}
+
+func (i instant) compare(o instant) int {
+	if c := cmp.Compare(i.seconds, o.seconds); c != 0 {
+		return c
+	}
+	return cmp.Compare(i.nanos, o.nanos)
+}
+
+// add returns the sum of the instants, or their difference if sign is -1
+func (i instant) add(o instant, sign int64) instant {
+	r := instant{i.seconds + sign*o.seconds, i.nanos + sign*o.nanos}
+	for r.nanos < 0 {
+		r.seconds--
+		r.nanos += int64(time.Second)
+	}
+	for r.nanos >= int64(time.Second) {
+		r.seconds++
+		r.nanos -= int64(time.Second)
+	}
+	return r
+}
+
+func (i instant) format(timestamp bool) string {
+	if timestamp {
+		return time.Unix(i.seconds, i.nanos).UTC().Format(time.RFC3339Nano)
+	}
+	if limit := int64(math.MaxInt64 / time.Second); i.seconds < limit && i.seconds > -limit {
+		return (time.Duration(i.seconds)*time.Second + time.Duration(i.nanos)).String()
+	}
+	return strconv.FormatInt(i.seconds, 10) + "s"
+}
+
+// time checks a Duration or Timestamp against its rules
+func (v *validator) time(m protoreflect.Message, kind string, tr protoreflect.Message, path string, vs *[]fieldViolation) {
+	add := func(rule, msg string, args ...interface{}) {
+		*vs = append(*vs, fieldViolation{Field: path, Rule: kind + "." + rule, Message: fmt.Sprintf(msg, args...)})
+	}
+	d := instantOf(m)
+	format := func(rv protoreflect.Value) string {
+		return instantOf(rv.Message()).format(kind == "timestamp")
+	}
+	t := time.Now()
+	now := instant{t.Unix(), int64(t.Nanosecond())}
+
+	tr.Range(func(rfd protoreflect.FieldDescriptor, rv protoreflect.Value) bool {
+		switch name := rfd.Name(); name {
+		case "const":
+			if d.compare(instantOf(rv.Message())) != 0 {
+				add("const", "value must equal %s", format(rv))
+			}
+		case "lt":
+			if d.compare(instantOf(rv.Message())) >= 0 {
+				add("lt", "value must be less than %s", format(rv))
+			}
+		case "lte":
+			if d.compare(instantOf(rv.Message())) > 0 {
+				add("lte", "value must be less than or equal to %s", format(rv))
+			}
+		case "gt":
+			if d.compare(instantOf(rv.Message())) <= 0 {
+				add("gt", "value must be greater than %s", format(rv))
+			}
+		case "gte":
+			if d.compare(instantOf(rv.Message())) < 0 {
+				add("gte", "value must be greater than or equal to %s", format(rv))
+			}
+		case "in", "not_in":
+			l := rv.List()
+			found := false
+			for i := 0; i < l.Len() && !found; i++ {
+				found = d.compare(instantOf(l.Get(i).Message())) == 0
+			}
+			if found != (name == "in") {
+				add(string(name), "value must %sbe in the list", map[bool]string{true: "", false: "not "}[name == "in"])
+			}
+		case "lt_now":
+			if rv.Bool() && d.compare(now) >= 0 {
+				add("lt_now", "value must be less than now")
+			}
+		case "gt_now":
+			if rv.Bool() && d.compare(now) <= 0 {
+				add("gt_now", "value must be greater than now")
+			}
+		case "within":
+			w := instantOf(rv.Message())
+			if d.compare(now.add(w, -1)) < 0 || d.compare(now.add(w, 1)) > 0 {
+				add("within", "value must be within %s of now", w.format(false))
+			}
+		}
+		return true
+	})
+}
+
+// validateRequest returns the violations of the request, unless the
+// workspace sends requests as they are
+func (a *api) validateRequest(conn *connection, method string, req protoreflect.ProtoMessage) error {
+	if conn.opts.SkipValidation {
+		return nil
+	}
+	vv := conn.requestValidator()
+	if vv == nil {
+		return nil
+	}
+	vs := vv.validate(req.ProtoReflect())
+	if len(vs) == 0 {
+		return nil
+	}
+	runtime.EventsEmit(a.ctx, eventValidationFailed, method, vs)
+	return errInvalidRequest(vs)
+}
+
+// ValidateRequest checks the request of the method on the current workspace
+// against the buf.validate and protoc-gen-validate rules of its fields,
+// returning the fields that break them
+func (a *api) ValidateRequest(method, stringJSON string) ([]fieldViolation, error) {
+	conn := a.current()
+	if conn == nil {
+		return nil, errors.New("no proto files loaded")
+	}
+	md, err := conn.methodDesc(method)
+	if err != nil {
+		return nil, err
+	}
+	req, err := conn.opts.requestFormat().decode(md.Input(), []byte(stringJSON))
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal request: %v", err)
+	}
+	vs := conn.requestValidator().validate(req)
+	if vs == nil {
+		vs = []fieldViolation{}
+	}
+	return vs, nil
+}
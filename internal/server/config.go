@@ -0,0 +1,166 @@
+package server
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const defaultAddr = ":5001"
+
+// Config configures the test server. The zero value serves plaintext on
+// :5001.
+type Config struct {
+	Addr string
+	// TLS serves with a certificate for localhost signed by a generated CA
+	TLS bool
+	// MTLS serves with TLS and also requires a client certificate signed by
+	// the generated CA
+	MTLS bool
+	// CertDir is where the CA, server and client certificates and keys are
+	// written as PEM, so clients can be set up to use them. A directory in
+	// the temp directory is used if empty.
+	CertDir string
+}
+
+// configFromEnv returns the config set by the WOMBAT_TEST_SERVER_ADDR,
+// WOMBAT_TEST_SERVER_TLS (tls or mtls) and WOMBAT_TEST_SERVER_CERTS
+// environment variables
+func configFromEnv() Config {
+	cfg := Config{
+		Addr:    os.Getenv("WOMBAT_TEST_SERVER_ADDR"),
+		CertDir: os.Getenv("WOMBAT_TEST_SERVER_CERTS"),
+	}
+	switch strings.ToLower(os.Getenv("WOMBAT_TEST_SERVER_TLS")) {
+	case "tls":
+		cfg.TLS = true
+	case "mtls":
+		cfg.MTLS = true
+	}
+	return cfg
+}
+
+// testCert is a generated certificate and its key
+type testCert struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	keyPEM  []byte
+}
+
+func newTestCert(tmpl *x509.Certificate, parent *testCert) (*testCert, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+	tmpl.SerialNumber = serial
+	tmpl.NotBefore = time.Now().Add(-time.Hour)
+	tmpl.NotAfter = time.Now().Add(365 * 24 * time.Hour)
+
+	parentCert, parentKey := tmpl, key
+	if parent != nil {
+		parentCert, parentKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parentCert, &key.PublicKey, parentKey)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return &testCert{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}, nil
+}
+
+// tlsConfig generates a CA with server and client certificates, writes them
+// to the cert dir and returns the server's TLS config
+func (cfg Config) tlsConfig() (*tls.Config, error) {
+	ca, err := newTestCert(&x509.Certificate{
+		Subject:               pkix.Name{Organization: []string{"Wombat"}, CommonName: "Wombat Test CA"},
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate CA: %v", err)
+	}
+	srv, err := newTestCert(&x509.Certificate{
+		Subject:     pkix.Name{Organization: []string{"Wombat"}, CommonName: "localhost"},
+		DNSNames:    []string{"localhost"},
+		IPAddresses: []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, ca)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate server certificate: %v", err)
+	}
+	client, err := newTestCert(&x509.Certificate{
+		Subject:     pkix.Name{Organization: []string{"Wombat"}, CommonName: "wombat-client"},
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, ca)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate client certificate: %v", err)
+	}
+
+	dir := cfg.CertDir
+	if dir == "" {
+		dir = filepath.Join(os.TempDir(), "wombat-test-server")
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create certificate directory: %v", err)
+	}
+	files := map[string][]byte{
+		"ca.pem":         ca.certPEM,
+		"server.pem":     srv.certPEM,
+		"server-key.pem": srv.keyPEM,
+		"client.pem":     client.certPEM,
+		"client-key.pem": client.keyPEM,
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0600); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %v", name, err)
+		}
+	}
+	fmt.Printf("server: test certificates written to %s\n", dir)
+
+	pair, err := tls.X509KeyPair(srv.certPEM, srv.keyPEM)
+	if err != nil {
+		return nil, err
+	}
+	tlsCfg := &tls.Config{
+		Certificates: []tls.Certificate{pair},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if cfg.MTLS {
+		pool := x509.NewCertPool()
+		pool.AddCert(ca.cert)
+		tlsCfg.ClientCAs = pool
+		tlsCfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return tlsCfg, nil
+}
@@ -9,10 +9,15 @@ import (
 	"math/rand"
 	"net"
 	"os"
+	"sort"
 	"sync"
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/channelz/service"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
@@ -23,11 +28,12 @@ func init() {
 	rand.Seed(time.Now().Unix())
 }
 
-//go:generate protoc --go_out=:. --go-grpc_out=:. route_guide.proto foobar.proto
+//go:generate protoc --go_out=:. --go-grpc_out=:. route_guide.proto foobar.proto testing.proto
 
 type server struct {
 	UnimplementedRouteGuideServer
 	UnimplementedFoobarServer
+	UnimplementedTestingServer
 
 	savedFeatures []*Feature // read-only after initialized
 
@@ -142,25 +148,145 @@ func (s *server) RecordRoute(stream RouteGuide_RecordRouteServer) error {
 	}
 }
 
+// RouteChat receives a stream of message/location pairs, and responds with a stream of all
+// previous messages at each of those locations.
+func (s *server) RouteChat(stream RouteGuide_RouteChatServer) error {
+	for {
+		in, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		key := serialize(in.Location)
+
+		s.mu.Lock()
+		s.routeNotes[key] = append(s.routeNotes[key], in)
+		// Note: this copy prevents blocking other clients while serving this one.
+		// We don't need to do a deep copy, because elements in the slice are
+		// insert-only and never modified.
+		rn := make([]*RouteNote, len(s.routeNotes[key]))
+		copy(rn, s.routeNotes[key])
+		s.mu.Unlock()
+
+		for _, note := range rn {
+			if err := stream.Send(note); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func serialize(point *Point) string {
+	return fmt.Sprintf("%d %d", point.GetLatitude(), point.GetLongitude())
+}
+
 // Empty is a empty request/response
 func (s *server) Empty(context.Context, *EmptyRequest) (*EmptyResponse, error) {
 	return &EmptyResponse{}, nil
 }
 
-// Serve stats serving a gRPC server that is used for testing
+// Foo echoes the request
+func (s *server) Foo(_ context.Context, req *FooRequest) (*FooResponse, error) {
+	return &FooResponse{Request: req}, nil
+}
+
+// Bar echoes the request with the number of values of its repeated fields
+func (s *server) Bar(_ context.Context, req *BarRequest) (*BarResponse, error) {
+	count := len(req.TypeRepeatedString) + len(req.TypeRepeatedFloat) +
+		len(req.TypeRepeatedBool) + len(req.TypeRepeatedEnum) +
+		len(req.TypeRepeatedBytes) + len(req.TypeRepeatedMessage) +
+		len(req.Uint32Repeated)
+	return &BarResponse{Request: req, Count: int32(count)}, nil
+}
+
+// Baz echoes the request with the name of the field set in its oneof
+func (s *server) Baz(_ context.Context, req *BazRequest) (*BazResponse, error) {
+	var field string
+	switch req.Request.(type) {
+	case *BazRequest_Foo:
+		field = "foo"
+	case *BazRequest_Bar:
+		field = "bar"
+	case *BazRequest_Baz:
+		field = "baz"
+	}
+	return &BazResponse{Request: req, Field: field}, nil
+}
+
+// AFoo echoes the request with the sorted keys of its string maps
+func (s *server) AFoo(_ context.Context, req *AFooRequest) (*AFooResponse, error) {
+	seen := make(map[string]bool)
+	for k := range req.MapStringString {
+		seen[k] = true
+	}
+	for k := range req.MapStringEnum {
+		seen[k] = true
+	}
+	for k := range req.MapStringBool {
+		seen[k] = true
+	}
+	keys := make([]string, 0, len(seen))
+	for k := range seen {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return &AFooResponse{Request: req, Keys: keys}, nil
+}
+
+// WellKnown echoes the request with the time it was received
+func (s *server) WellKnown(_ context.Context, req *WellKnownRequest) (*WellKnownResponse, error) {
+	return &WellKnownResponse{Request: req, Received: timestamppb.Now()}, nil
+}
+
+// SingleOneof echoes the request and whether its oneof is set
+func (s *server) SingleOneof(_ context.Context, req *SingleOneofRequest) (*SingleOneofResponse, error) {
+	return &SingleOneofResponse{Request: req, Set: req.Foobar != nil}, nil
+}
+
+// Serve stats serving a gRPC server that is used for testing, configured by
+// the environment
 func Serve() {
-	lis, err := net.Listen("tcp", ":5001")
+	if err := ServeConfig(configFromEnv()); err != nil {
+		fmt.Fprintf(os.Stderr, "server: %v\n", err)
+	}
+}
+
+// ServeConfig serves the gRPC server used for testing until it fails
+func ServeConfig(cfg Config) error {
+	if cfg.Addr == "" {
+		cfg.Addr = defaultAddr
+	}
+	var opts []grpc.ServerOption
+	if cfg.TLS || cfg.MTLS {
+		tlsCfg, err := cfg.tlsConfig()
+		if err != nil {
+			return err
+		}
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsCfg)))
+	}
+
+	lis, err := net.Listen("tcp", cfg.Addr)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "server: failed to create listener: %v", err)
+		return fmt.Errorf("failed to create listener: %v", err)
 	}
 
 	e, _ := protojson.Marshal(&WellKnownRequest{Timestamp: timestamppb.Now()})
 	fmt.Printf("string(e) = %+v\n", string(e))
 
 	s := newServer()
-	gs := grpc.NewServer()
+	gs := grpc.NewServer(opts...)
 	RegisterRouteGuideServer(gs, s)
 	RegisterFoobarServer(gs, s)
+	RegisterTestingServer(gs, s)
+
+	hs := health.NewServer()
+	for name := range gs.GetServiceInfo() {
+		hs.SetServingStatus(name, healthpb.HealthCheckResponse_SERVING)
+	}
+	healthpb.RegisterHealthServer(gs, hs)
+	service.RegisterChannelzServiceToServer(gs)
 	reflection.Register(gs)
-	gs.Serve(lis)
+	return gs.Serve(lis)
 }
@@ -0,0 +1,152 @@
+package server
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	maxLargeSize       = 64 << 20
+	defaultStreamCount = 10
+)
+
+// Error fails with the code and message of the request, with an example of
+// every error detail type if asked for
+func (s *server) Error(_ context.Context, req *ErrorRequest) (*ErrorResponse, error) {
+	code := codes.Code(req.Code)
+	if code == codes.OK {
+		code = codes.Unknown
+	}
+	st := status.New(code, req.Message)
+	if !req.Details {
+		return nil, st.Err()
+	}
+
+	st, err := st.WithDetails(
+		&errdetails.ErrorInfo{
+			Reason:   "TEST_ERROR",
+			Domain:   "wombat.v1.Testing",
+			Metadata: map[string]string{"code": code.String()},
+		},
+		&errdetails.RetryInfo{RetryDelay: durationpb.New(time.Second)},
+		&errdetails.DebugInfo{
+			StackEntries: []string{"server.(*server).Error"},
+			Detail:       "returned on request",
+		},
+		&errdetails.QuotaFailure{Violations: []*errdetails.QuotaFailure_Violation{
+			{Subject: "client:wombat", Description: "Daily limit exceeded"},
+		}},
+		&errdetails.PreconditionFailure{Violations: []*errdetails.PreconditionFailure_Violation{
+			{Type: "TOS", Subject: "wombat.dev", Description: "Terms of service not accepted"},
+		}},
+		&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: "message", Description: "Must not be used in tests"},
+		}},
+		&errdetails.RequestInfo{RequestId: time.Now().Format(time.RFC3339Nano), ServingData: "test server"},
+		&errdetails.ResourceInfo{
+			ResourceType: "wombat.v1.Feature",
+			ResourceName: "features/1",
+			Owner:        "wombat",
+			Description:  "Feature not found",
+		},
+		&errdetails.Help{Links: []*errdetails.Help_Link{
+			{Description: "gRPC error model", Url: "https://grpc.io/docs/guides/error/"},
+		}},
+		&errdetails.LocalizedMessage{Locale: "en-US", Message: req.Message},
+	)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to add error details: %v", err)
+	}
+	return nil, st.Err()
+}
+
+// Metadata sends the headers and trailers of the request and echoes the
+// metadata it received
+func (s *server) Metadata(ctx context.Context, req *MetadataRequest) (*MetadataResponse, error) {
+	if err := grpc.SetHeader(ctx, metadata.New(req.Header)); err != nil {
+		return nil, err
+	}
+	if err := grpc.SetTrailer(ctx, metadata.New(req.Trailer)); err != nil {
+		return nil, err
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	received := make(map[string]string, len(md))
+	for k, vs := range md {
+		vs = append([]string(nil), vs...)
+		sort.Strings(vs)
+		received[k] = strings.Join(vs, ", ")
+	}
+	return &MetadataResponse{Received: received}, nil
+}
+
+// Large returns a payload of the requested size
+func (s *server) Large(_ context.Context, req *LargeRequest) (*LargeResponse, error) {
+	if req.Size < 0 || req.Size > maxLargeSize {
+		return nil, status.Errorf(codes.InvalidArgument, "size must be between 0 and %d", maxLargeSize)
+	}
+	payload := make([]byte, req.Size)
+	for i := range payload {
+		payload[i] = byte('a' + i%26)
+	}
+	return &LargeResponse{Payload: payload}, nil
+}
+
+// SlowStream sends the requested number of responses, 10 by default, waiting
+// for the interval, a second by default, before each one
+func (s *server) SlowStream(req *SlowStreamRequest, stream Testing_SlowStreamServer) error {
+	count := req.Count
+	if count <= 0 {
+		count = defaultStreamCount
+	}
+	interval := time.Second
+	if req.Interval != nil {
+		interval = req.Interval.AsDuration()
+		if interval <= 0 {
+			return status.Error(codes.InvalidArgument, "interval must be positive")
+		}
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for i := int32(0); i < count; i++ {
+		select {
+		case <-ticker.C:
+		case <-stream.Context().Done():
+			return status.FromContextError(stream.Context().Err()).Err()
+		}
+		if err := stream.Send(&SlowStreamResponse{Index: i, Sent: timestamppb.Now()}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Deadline reports the deadline of the call, then waits for the delay of the
+// request unless the deadline is reached first
+func (s *server) Deadline(ctx context.Context, req *DeadlineRequest) (*DeadlineResponse, error) {
+	resp := &DeadlineResponse{}
+	if dl, ok := ctx.Deadline(); ok {
+		resp.HasDeadline = true
+		resp.Remaining = durationpb.New(time.Until(dl))
+	}
+
+	t := time.NewTimer(req.Delay.AsDuration())
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return resp, nil
+	case <-ctx.Done():
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+}
@@ -0,0 +1,299 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v3.19.6
+// source: testing.proto
+
+package server
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Testing_Error_FullMethodName      = "/wombat.v1.Testing/Error"
+	Testing_Metadata_FullMethodName   = "/wombat.v1.Testing/Metadata"
+	Testing_Large_FullMethodName      = "/wombat.v1.Testing/Large"
+	Testing_SlowStream_FullMethodName = "/wombat.v1.Testing/SlowStream"
+	Testing_Deadline_FullMethodName   = "/wombat.v1.Testing/Deadline"
+)
+
+// TestingClient is the client API for Testing service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Testing has methods for the cases a client has to handle besides a
+// successful response.
+type TestingClient interface {
+	// Error fails with the code and message, and with rich error details if
+	// asked for.
+	Error(ctx context.Context, in *ErrorRequest, opts ...grpc.CallOption) (*ErrorResponse, error)
+	// Metadata sends the headers and trailers, and echoes the metadata of the
+	// request.
+	Metadata(ctx context.Context, in *MetadataRequest, opts ...grpc.CallOption) (*MetadataResponse, error)
+	// Large returns a payload of the given size.
+	Large(ctx context.Context, in *LargeRequest, opts ...grpc.CallOption) (*LargeResponse, error)
+	// SlowStream sends the number of responses with the interval between them.
+	SlowStream(ctx context.Context, in *SlowStreamRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SlowStreamResponse], error)
+	// Deadline waits for the delay, failing with DEADLINE_EXCEEDED if the
+	// deadline of the call is reached first.
+	Deadline(ctx context.Context, in *DeadlineRequest, opts ...grpc.CallOption) (*DeadlineResponse, error)
+}
+
+type testingClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTestingClient(cc grpc.ClientConnInterface) TestingClient {
+	return &testingClient{cc}
+}
+
+func (c *testingClient) Error(ctx context.Context, in *ErrorRequest, opts ...grpc.CallOption) (*ErrorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ErrorResponse)
+	err := c.cc.Invoke(ctx, Testing_Error_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testingClient) Metadata(ctx context.Context, in *MetadataRequest, opts ...grpc.CallOption) (*MetadataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MetadataResponse)
+	err := c.cc.Invoke(ctx, Testing_Metadata_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testingClient) Large(ctx context.Context, in *LargeRequest, opts ...grpc.CallOption) (*LargeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LargeResponse)
+	err := c.cc.Invoke(ctx, Testing_Large_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testingClient) SlowStream(ctx context.Context, in *SlowStreamRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SlowStreamResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Testing_ServiceDesc.Streams[0], Testing_SlowStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SlowStreamRequest, SlowStreamResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Testing_SlowStreamClient = grpc.ServerStreamingClient[SlowStreamResponse]
+
+func (c *testingClient) Deadline(ctx context.Context, in *DeadlineRequest, opts ...grpc.CallOption) (*DeadlineResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeadlineResponse)
+	err := c.cc.Invoke(ctx, Testing_Deadline_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TestingServer is the server API for Testing service.
+// All implementations must embed UnimplementedTestingServer
+// for forward compatibility.
+//
+// Testing has methods for the cases a client has to handle besides a
+// successful response.
+type TestingServer interface {
+	// Error fails with the code and message, and with rich error details if
+	// asked for.
+	Error(context.Context, *ErrorRequest) (*ErrorResponse, error)
+	// Metadata sends the headers and trailers, and echoes the metadata of the
+	// request.
+	Metadata(context.Context, *MetadataRequest) (*MetadataResponse, error)
+	// Large returns a payload of the given size.
+	Large(context.Context, *LargeRequest) (*LargeResponse, error)
+	// SlowStream sends the number of responses with the interval between them.
+	SlowStream(*SlowStreamRequest, grpc.ServerStreamingServer[SlowStreamResponse]) error
+	// Deadline waits for the delay, failing with DEADLINE_EXCEEDED if the
+	// deadline of the call is reached first.
+	Deadline(context.Context, *DeadlineRequest) (*DeadlineResponse, error)
+	mustEmbedUnimplementedTestingServer()
+}
+
+// UnimplementedTestingServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTestingServer struct{}
+
+func (UnimplementedTestingServer) Error(context.Context, *ErrorRequest) (*ErrorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Error not implemented")
+}
+func (UnimplementedTestingServer) Metadata(context.Context, *MetadataRequest) (*MetadataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Metadata not implemented")
+}
+func (UnimplementedTestingServer) Large(context.Context, *LargeRequest) (*LargeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Large not implemented")
+}
+func (UnimplementedTestingServer) SlowStream(*SlowStreamRequest, grpc.ServerStreamingServer[SlowStreamResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method SlowStream not implemented")
+}
+func (UnimplementedTestingServer) Deadline(context.Context, *DeadlineRequest) (*DeadlineResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Deadline not implemented")
+}
+func (UnimplementedTestingServer) mustEmbedUnimplementedTestingServer() {}
+func (UnimplementedTestingServer) testEmbeddedByValue()                 {}
+
+// UnsafeTestingServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TestingServer will
+// result in compilation errors.
+type UnsafeTestingServer interface {
+	mustEmbedUnimplementedTestingServer()
+}
+
+func RegisterTestingServer(s grpc.ServiceRegistrar, srv TestingServer) {
+	// If the following call pancis, it indicates UnimplementedTestingServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Testing_ServiceDesc, srv)
+}
+
+func _Testing_Error_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ErrorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestingServer).Error(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Testing_Error_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestingServer).Error(ctx, req.(*ErrorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Testing_Metadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MetadataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestingServer).Metadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Testing_Metadata_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestingServer).Metadata(ctx, req.(*MetadataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Testing_Large_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LargeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestingServer).Large(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Testing_Large_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestingServer).Large(ctx, req.(*LargeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Testing_SlowStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SlowStreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TestingServer).SlowStream(m, &grpc.GenericServerStream[SlowStreamRequest, SlowStreamResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Testing_SlowStreamServer = grpc.ServerStreamingServer[SlowStreamResponse]
+
+func _Testing_Deadline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeadlineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestingServer).Deadline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Testing_Deadline_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestingServer).Deadline(ctx, req.(*DeadlineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Testing_ServiceDesc is the grpc.ServiceDesc for Testing service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Testing_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "wombat.v1.Testing",
+	HandlerType: (*TestingServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Error",
+			Handler:    _Testing_Error_Handler,
+		},
+		{
+			MethodName: "Metadata",
+			Handler:    _Testing_Metadata_Handler,
+		},
+		{
+			MethodName: "Large",
+			Handler:    _Testing_Large_Handler,
+		},
+		{
+			MethodName: "Deadline",
+			Handler:    _Testing_Deadline_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SlowStream",
+			Handler:       _Testing_SlowStream_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "testing.proto",
+}